@@ -102,7 +102,7 @@ func (c *EMVQR) GeneratePayload() (string, error) {
 	if c.DataPayloadFormatIndicator != "" {
 		s += format(IDPayloadFormatIndicator, toHex(c.DataPayloadFormatIndicator))
 	} else {
-		return "", fmt.Errorf("DataPayloadFormatIndicator is mandatory")
+		return "", &ErrMandatoryMissing{Tag: IDPayloadFormatIndicator}
 	}
 	if len(c.ApplicationTemplates) > 0 {
 		for _, t := range c.ApplicationTemplates {
@@ -130,7 +130,7 @@ func (c *EMVQR) GeneratePayload() (string, error) {
 	}
 	decoded, err := hex.DecodeString(s)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%v: %w", err, ErrInvalidFormat)
 	}
 	s = base64.StdEncoding.EncodeToString([]byte(string(decoded)))
 	return s, nil
@@ -138,9 +138,10 @@ func (c *EMVQR) GeneratePayload() (string, error) {
 
 // Decode ...
 func (c *EMVQR) Decode(payload string) (*EMVQR, error) {
+	const fnDecode = "Decode"
 	s, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
-		return nil, err
+		return nil, formatError(fnDecode, err)
 	}
 	encoded := hex.EncodeToString(s)
 	emvqr := new(EMVQR)
@@ -154,30 +155,34 @@ func (c *EMVQR) Decode(payload string) (*EMVQR, error) {
 		case IDPayloadFormatIndicator:
 			value, err := fromHex(hexValue)
 			if err != nil {
-				return nil, err
+				return nil, p.fail(fnDecode, idWordCount, id, err)
 			}
 			emvqr.DataPayloadFormatIndicator = value
 		case IDApplicationTemplate:
 			applicationTemplate, err := c.ParseApplication(hexValue)
 			if err != nil {
-				return nil, err
+				return nil, p.fail(fnDecode, idWordCount, id, err)
 			}
 			emvqr.ApplicationTemplates = append(emvqr.ApplicationTemplates, *applicationTemplate)
 		case IDCommonDataTemplate:
 			commonDataTemplate, err := c.ParseCommonDataTemplate(hexValue)
 			if err != nil {
-				return nil, err
+				return nil, p.fail(fnDecode, idWordCount, id, err)
 			}
 			emvqr.CommonDataTemplates = append(emvqr.CommonDataTemplates, *commonDataTemplate)
 		default:
 			// nothing
 		}
 	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
 	return emvqr, nil
 }
 
 // ParseApplication ...
 func (c *EMVQR) ParseApplication(hexString string) (*ApplicationTemplate, error) {
+	const fnParse = "ParseApplication"
 	applicationTemplate := new(ApplicationTemplate)
 	p := NewParser(hexString)
 	idWordCount := IDWordCount
@@ -188,7 +193,7 @@ func (c *EMVQR) ParseApplication(hexString string) (*ApplicationTemplate, error)
 		hexVal := p.Value(idWordCount)
 		value, err := fromHex(hexVal)
 		if err != nil {
-			return nil, err
+			return nil, p.fail(fnParse, idWordCount, id, err)
 		}
 		switch id {
 		case TagApplicationDefinitionFileName:
@@ -202,7 +207,7 @@ func (c *EMVQR) ParseApplication(hexString string) (*ApplicationTemplate, error)
 		case IDApplicationSpecificTransparentTemplate:
 			bertlv, err := c.ParseBERTLV(hexVal)
 			if err != nil {
-				return nil, err
+				return nil, p.fail(fnParse, idWordCount, id, err)
 			}
 			applicationTemplate.ApplicationSpecificTransparentTemplates = append(
 				applicationTemplate.ApplicationSpecificTransparentTemplates,
@@ -219,12 +224,14 @@ func (c *EMVQR) ParseApplication(hexString string) (*ApplicationTemplate, error)
 				}
 			} else {
 				idWordCount = 4
-				// id = strings.ToUpper(string(p.ID(idWordCount)))
+				// the value read with a two digit tag may not fit
+				p.err = nil
+				id = strings.ToUpper(string(p.ID(idWordCount)))
 				//length = p.ValueLength(idWordCount)
 				hexVal = p.Value(idWordCount)
 				value, err = fromHex(hexVal)
 				if err != nil {
-					return nil, err
+					return nil, p.fail(fnParse, idWordCount, id, err)
 				}
 				switch id {
 				case TagCardholderName:
@@ -262,11 +269,15 @@ func (c *EMVQR) ParseApplication(hexString string) (*ApplicationTemplate, error)
 			}
 		}
 	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
 	return applicationTemplate, nil
 }
 
 // ParseCommonDataTemplate ...
 func (c *EMVQR) ParseCommonDataTemplate(hexString string) (*CommonDataTemplate, error) {
+	const fnParse = "ParseCommonDataTemplate"
 	commonDataTemplate := new(CommonDataTemplate)
 	p := NewParser(hexString)
 	idWordCount := IDWordCount
@@ -277,7 +288,7 @@ func (c *EMVQR) ParseCommonDataTemplate(hexString string) (*CommonDataTemplate,
 		hexVal := p.Value(idWordCount)
 		value, err := fromHex(hexVal)
 		if err != nil {
-			return nil, err
+			return nil, p.fail(fnParse, idWordCount, id, err)
 		}
 		switch id {
 		case TagApplicationDefinitionFileName:
@@ -291,7 +302,7 @@ func (c *EMVQR) ParseCommonDataTemplate(hexString string) (*CommonDataTemplate,
 		case IDCommonDataTransparentTemplate:
 			bertlv, err := c.ParseBERTLV(hexVal)
 			if err != nil {
-				return nil, err
+				return nil, p.fail(fnParse, idWordCount, id, err)
 			}
 			commonDataTemplate.CommonDataTransparentTemplates = append(
 				commonDataTemplate.CommonDataTransparentTemplates,
@@ -308,12 +319,14 @@ func (c *EMVQR) ParseCommonDataTemplate(hexString string) (*CommonDataTemplate,
 				}
 			} else {
 				idWordCount = 4
+				// the value read with a two digit tag may not fit
+				p.err = nil
 				id = strings.ToUpper(string(p.ID(idWordCount)))
 				//length = p.ValueLength(idWordCount)
 				hexVal = p.Value(idWordCount)
 				value, err = fromHex(hexVal)
 				if err != nil {
-					return nil, err
+					return nil, p.fail(fnParse, idWordCount, id, err)
 				}
 				switch id {
 				case TagCardholderName:
@@ -351,11 +364,15 @@ func (c *EMVQR) ParseCommonDataTemplate(hexString string) (*CommonDataTemplate,
 			}
 		}
 	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
 	return commonDataTemplate, nil
 }
 
 // ParseBERTLV ...
 func (c *EMVQR) ParseBERTLV(hexString string) (*BERTLV, error) {
+	const fnParse = "ParseBERTLV"
 	bertlv := new(BERTLV)
 	p := NewParser(hexString)
 	idWordCount := IDWordCount
@@ -366,7 +383,7 @@ func (c *EMVQR) ParseBERTLV(hexString string) (*BERTLV, error) {
 		hexVal := p.Value(idWordCount)
 		value, err := fromHex(hexVal)
 		if err != nil {
-			return nil, err
+			return nil, p.fail(fnParse, idWordCount, id, err)
 		}
 		switch id {
 		case TagApplicationDefinitionFileName:
@@ -386,12 +403,14 @@ func (c *EMVQR) ParseBERTLV(hexString string) (*BERTLV, error) {
 				}
 			} else {
 				idWordCount = 4
+				// the value read with a two digit tag may not fit
+				p.err = nil
 				id = strings.ToUpper(string(p.ID(idWordCount)))
 				//length = p.ValueLength(idWordCount)
 				hexVal = p.Value(idWordCount)
 				value, err = fromHex(hexVal)
 				if err != nil {
-					return nil, err
+					return nil, p.fail(fnParse, idWordCount, id, err)
 				}
 				switch id {
 				case TagCardholderName:
@@ -429,6 +448,9 @@ func (c *EMVQR) ParseBERTLV(hexString string) (*BERTLV, error) {
 			}
 		}
 	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
 	return bertlv, nil
 }
 
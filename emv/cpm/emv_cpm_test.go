@@ -0,0 +1,26 @@
+package cpm
+
+import (
+	"testing"
+)
+
+func TestEMVQR_Decode(t *testing.T) {
+	payload := "hQVDUFYwMWETTwegAAAAVVVVUAhQcm9kdWN0MWETTwegAAAAZmZmUAhQcm9kdWN0MmJJWggSNFZ4kBI0WF8gDkNBUkRIT0xERVIvRU1WXy0IcnVlc2RlZW5kIZ8QBwYBCgMAAACfJghYT9OF+iNLzJ82AgABnzcEbVjvEw=="
+	got, err := new(EMVQR).Decode(payload)
+	if err != nil {
+		t.Fatalf("EMVQR.Decode() error = %v", err)
+	}
+	if n := len(got.ApplicationTemplates); n != 2 {
+		t.Fatalf("ApplicationTemplates = %d, want 2", n)
+	}
+	if n := len(got.CommonDataTemplates); n != 1 {
+		t.Fatalf("CommonDataTemplates = %d, want 1", n)
+	}
+	cdt := got.CommonDataTemplates[0]
+	if cdt.DataCardholderName != "CARDHOLDER/EMV" || cdt.DataLanguagePreference != "ruesdeen" {
+		t.Errorf("CommonDataTemplate = %+v, want the cardholder name and language preference", cdt.BERTLV)
+	}
+	if n := len(cdt.CommonDataTransparentTemplates); n != 1 || cdt.CommonDataTransparentTemplates[0].DataUnpredictableNumber != "6d58ef13" {
+		t.Errorf("CommonDataTransparentTemplates = %+v", cdt.CommonDataTransparentTemplates)
+	}
+}
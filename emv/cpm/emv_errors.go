@@ -0,0 +1,56 @@
+package cpm
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Error kinds. Errors returned by this package can be matched against them
+// with errors.Is.
+var (
+	ErrTruncated     = errors.New("payload truncated")
+	ErrUnknownTag    = errors.New("unknown tag")
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+// ErrMandatoryMissing is returned when a mandatory data object is missing.
+type ErrMandatoryMissing struct {
+	Tag string
+}
+
+func (e *ErrMandatoryMissing) Error() string {
+	return "mandatory data object missing. tag: " + e.Tag
+}
+
+// Is reports whether target is an *ErrMandatoryMissing for the same tag, or
+// for any tag if target has none.
+func (e *ErrMandatoryMissing) Is(target error) bool {
+	t, ok := target.(*ErrMandatoryMissing)
+	return ok && (t.Tag == "" || t.Tag == e.Tag)
+}
+
+// ErrInvalidLength is returned when the length field of a data object is not
+// a hex number, or exceeds the remaining payload.
+type ErrInvalidLength struct {
+	Tag    string
+	Offset int64 // hex digit offset of the data object
+	Err    error
+}
+
+func (e *ErrInvalidLength) Error() string {
+	return "invalid length. tag: " + e.Tag + ", offset: " + strconv.FormatInt(e.Offset, 10) + ": " + e.Err.Error()
+}
+
+// Unwrap ...
+func (e *ErrInvalidLength) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidFormat or an *ErrInvalidLength.
+func (e *ErrInvalidLength) Is(target error) bool {
+	if target == ErrInvalidFormat {
+		return true
+	}
+	_, ok := target.(*ErrInvalidLength)
+	return ok
+}
@@ -0,0 +1,116 @@
+package cpm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func fromHexPayload(t *testing.T, s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestDecode_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		target     error
+		wantPath   string
+		wantOffset int64
+	}{
+		{
+			name:       "truncated value",
+			payload:    "8505435056",
+			target:     ErrTruncated,
+			wantPath:   "85",
+			wantOffset: 0,
+		},
+		{
+			name:       "invalid length",
+			payload:    "85054350563031" + "6105",
+			target:     ErrInvalidFormat,
+			wantPath:   "61",
+			wantOffset: 14,
+		},
+		{
+			name:       "invalid length in template",
+			payload:    "85054350563031" + "61044F07A000",
+			target:     &ErrInvalidLength{},
+			wantPath:   "61.4F",
+			wantOffset: 18,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := new(EMVQR).Decode(fromHexPayload(t, tt.payload))
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("EMVQR.Decode() error = %v, want %v", err, tt.target)
+			}
+			var perr *ParserError
+			if !errors.As(err, &perr) {
+				t.Fatalf("EMVQR.Decode() error = %T, want *ParserError", err)
+			}
+			if perr.Path != tt.wantPath || perr.Offset != tt.wantOffset {
+				t.Errorf("ParserError path = %v, offset = %v, want %v, %v", perr.Path, perr.Offset, tt.wantPath, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestDecode_InvalidBase64(t *testing.T) {
+	if _, err := new(EMVQR).Decode("not base64!"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("EMVQR.Decode() error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestErrInvalidLength(t *testing.T) {
+	_, err := new(EMVQR).Decode(fromHexPayload(t, "85054350563031"+"61044F07A000"))
+	var lerr *ErrInvalidLength
+	if !errors.As(err, &lerr) {
+		t.Fatalf("EMVQR.Decode() error = %v, want *ErrInvalidLength", err)
+	}
+	if lerr.Tag != "61.4F" || lerr.Offset != 18 {
+		t.Errorf("ErrInvalidLength = %+v, want tag 61.4F at offset 18", lerr)
+	}
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("EMVQR.Decode() error = %v, want ErrTruncated", err)
+	}
+}
+
+func TestErrMandatoryMissing(t *testing.T) {
+	_, err := new(EMVQR).GeneratePayload()
+	tests := []struct {
+		target error
+		want   bool
+	}{
+		{target: &ErrMandatoryMissing{Tag: IDPayloadFormatIndicator}, want: true},
+		{target: &ErrMandatoryMissing{}, want: true},
+		{target: &ErrMandatoryMissing{Tag: IDApplicationTemplate}, want: false},
+		{target: ErrInvalidFormat, want: false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%v, %v) = %v, want %v", err, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestParserError_Nested(t *testing.T) {
+	err := valueOutOfRangeError("Value", 2, 10, 6, 20).at(2, "4F").nested(14, "61")
+	if err.Path != "61.4F" || err.Offset != 16 {
+		t.Errorf("ParserError path = %v, offset = %v, want 61.4F, 16", err.Path, err.Offset)
+	}
+	var lerr *ErrInvalidLength
+	if !errors.As(err, &lerr) || lerr.Tag != "61.4F" || lerr.Offset != 16 {
+		t.Errorf("ErrInvalidLength = %+v, want tag 61.4F at offset 16", lerr)
+	}
+	want := "parser.Value: invalid length. tag: 61.4F, offset: 16: bounds out of range. current: 2, max: 10, start: 6, end: 20: payload truncated (path: 61.4F, offset: 16)"
+	if got := err.Error(); got != want {
+		t.Errorf("ParserError.Error() = %v, want %v", got, want)
+	}
+}
@@ -4,17 +4,49 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 )
 
 // ParserError ...
 type ParserError struct {
-	Func string
-	Err  error
+	Func   string
+	Err    error
+	Offset int64  // hex digit offset of the data object where parsing stopped
+	Path   string // tag path of the data object where parsing stopped, e.g. "61.4F"
 }
 
 func (e *ParserError) Error() string {
-	return "parser." + e.Func + ": " + e.Err.Error()
+	if e.Path == "" {
+		return "parser." + e.Func + ": " + e.Err.Error()
+	}
+	return "parser." + e.Func + ": " + e.Err.Error() + " (path: " + e.Path + ", offset: " + strconv.FormatInt(e.Offset, 10) + ")"
+}
+
+// Unwrap ...
+func (e *ParserError) Unwrap() error {
+	return e.Err
+}
+
+// at sets the position where parsing stopped.
+func (e *ParserError) at(offset int64, path string) *ParserError {
+	e.Offset = offset
+	e.Path = path
+	if l, ok := e.Err.(*ErrInvalidLength); ok {
+		l.Offset = offset
+		l.Tag = path
+	}
+	return e
+}
+
+// nested moves the position of an error returned while parsing the value of
+// a template to the enclosing payload.
+func (e *ParserError) nested(offset int64, id string) *ParserError {
+	path := id
+	if e.Path != "" {
+		path += "." + e.Path
+	}
+	return e.at(offset+e.Offset, path)
 }
 
 func notCallError(fn string) *ParserError {
@@ -27,21 +59,39 @@ func notCallError(fn string) *ParserError {
 func outOfRangeError(fn string, current, max, start, end int64) *ParserError {
 	return &ParserError{
 		Func: fn,
-		Err:  fmt.Errorf("bounds out of range. current: %d, max: %d, start: %d, end: %d", current, max, start, end),
+		Err:  fmt.Errorf("bounds out of range. current: %d, max: %d, start: %d, end: %d: %w", current, max, start, end, ErrTruncated),
 	}
 }
 
 func syntaxError(fn, str string) *ParserError {
 	return &ParserError{
 		Func: fn,
-		Err:  errors.New("parsing " + strconv.Quote(str) + ": " + strconv.ErrSyntax.Error()),
+		Err: &ErrInvalidLength{
+			Err: errors.New("parsing " + strconv.Quote(str) + ": " + strconv.ErrSyntax.Error()),
+		},
+	}
+}
+
+func valueOutOfRangeError(fn string, current, max, start, end int64) *ParserError {
+	return &ParserError{
+		Func: fn,
+		Err: &ErrInvalidLength{
+			Err: outOfRangeError(fn, current, max, start, end).Err,
+		},
 	}
 }
 
 func idRangeError(fn string, id ID) *ParserError {
 	return &ParserError{
 		Func: fn,
-		Err:  errors.New("id range invalid. id: " + id.String()),
+		Err:  fmt.Errorf("id range invalid. id: %s: %w", id.String(), ErrUnknownTag),
+	}
+}
+
+func formatError(fn string, err error) *ParserError {
+	return &ParserError{
+		Func: fn,
+		Err:  fmt.Errorf("%v: %w", err, ErrInvalidFormat),
 	}
 }
 
@@ -99,7 +149,7 @@ func (p *Parser) ID(idWordCount int) ID {
 		return ID("")
 	}
 	if p.max < end {
-		p.err = outOfRangeError(fnID, p.current, p.max, start, end)
+		p.err = outOfRangeError(fnID, p.current, p.max, start, end).at(p.current, "")
 		return ID("")
 	}
 	id := ID(string(p.source[start:end]))
@@ -116,13 +166,13 @@ func (p *Parser) ValueLength(idWordCount int) int64 {
 		return 0
 	}
 	if p.max < end {
-		p.err = outOfRangeError(fnValueLength, p.current, p.max, start, end)
+		p.err = outOfRangeError(fnValueLength, p.current, p.max, start, end).at(p.current, p.path(idWordCount))
 		return 0
 	}
 	strValueLength := string(p.source[start:end])
 	len, err := strconv.ParseInt(strValueLength, 16, 64)
 	if err != nil {
-		p.err = syntaxError(fnValueLength, strValueLength)
+		p.err = syntaxError(fnValueLength, strValueLength).at(p.current, p.path(idWordCount))
 		return 0
 	}
 	return len * 2 // len divided by 2 in generate payload
@@ -138,7 +188,7 @@ func (p *Parser) Value(idWordCount int) string {
 		return ""
 	}
 	if p.max < end {
-		p.err = outOfRangeError(fnValue, p.current, p.max, start, end)
+		p.err = valueOutOfRangeError(fnValue, p.current, p.max, start, end).at(p.current, p.path(idWordCount))
 		return ""
 	}
 	return string(p.source[start:end])
@@ -148,3 +198,26 @@ func (p *Parser) Value(idWordCount int) string {
 func (p *Parser) Err() error {
 	return p.err
 }
+
+// path returns the ID at the current position, if there is one.
+func (p *Parser) path(idWordCount int) string {
+	end := p.current + int64(idWordCount)
+	if p.current < 0 || p.max < end {
+		return ""
+	}
+	return strings.ToUpper(string(p.source[p.current:end]))
+}
+
+// valueOffset returns the offset of the current value.
+func (p *Parser) valueOffset(idWordCount int) int64 {
+	return p.current + int64(idWordCount) + ValueLengthWordCount
+}
+
+// fail returns err, returned while decoding or parsing the current value,
+// as a *ParserError positioned at the current data object.
+func (p *Parser) fail(fn string, idWordCount int, id string, err error) error {
+	if e, ok := err.(*ParserError); ok {
+		return e.nested(p.valueOffset(idWordCount), id)
+	}
+	return formatError(fn, err).at(p.current, id)
+}
@@ -0,0 +1,128 @@
+package mpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChannelMedia is the first character of a Merchant Channel: the media the
+// QR code is shown on.
+type ChannelMedia byte
+
+// const ...
+const (
+	ChannelMediaPrintSticker  ChannelMedia = '0'
+	ChannelMediaPrintBill     ChannelMedia = '1'
+	ChannelMediaPrintMagazine ChannelMedia = '2'
+	ChannelMediaPrintOther    ChannelMedia = '3'
+	ChannelMediaScreenPOS     ChannelMedia = '4'
+	ChannelMediaScreenWebsite ChannelMedia = '5'
+	ChannelMediaScreenApp     ChannelMedia = '6'
+	ChannelMediaScreenOther   ChannelMedia = '7'
+)
+
+// ChannelLocation is the second character of a Merchant Channel: where the
+// transaction takes place.
+type ChannelLocation byte
+
+// const ...
+const (
+	ChannelLocationAtPremises     ChannelLocation = '0'
+	ChannelLocationNotAtPremises  ChannelLocation = '1'
+	ChannelLocationRemoteCommerce ChannelLocation = '2'
+	ChannelLocationOther          ChannelLocation = '3'
+)
+
+// ChannelPresence is the third character of a Merchant Channel: how the
+// merchant is present at the point of interaction.
+type ChannelPresence byte
+
+// const ...
+const (
+	ChannelPresenceAttended     ChannelPresence = '0'
+	ChannelPresenceUnattended   ChannelPresence = '1'
+	ChannelPresenceSemiAttended ChannelPresence = '2'
+	ChannelPresenceOther        ChannelPresence = '3'
+)
+
+var channelMediaNames = map[ChannelMedia]string{
+	ChannelMediaPrintSticker:  "print/merchant sticker",
+	ChannelMediaPrintBill:     "print/bill or invoice",
+	ChannelMediaPrintMagazine: "print/magazine or poster",
+	ChannelMediaPrintOther:    "print/other",
+	ChannelMediaScreenPOS:     "screen/merchant POS or POI",
+	ChannelMediaScreenWebsite: "screen/website",
+	ChannelMediaScreenApp:     "screen/app",
+	ChannelMediaScreenOther:   "screen/other",
+}
+
+var channelLocationNames = map[ChannelLocation]string{
+	ChannelLocationAtPremises:     "at merchant premises",
+	ChannelLocationNotAtPremises:  "not at merchant premises",
+	ChannelLocationRemoteCommerce: "remote commerce",
+	ChannelLocationOther:          "other location",
+}
+
+var channelPresenceNames = map[ChannelPresence]string{
+	ChannelPresenceAttended:     "attended POI",
+	ChannelPresenceUnattended:   "unattended",
+	ChannelPresenceSemiAttended: "semi-attended",
+	ChannelPresenceOther:        "other presence",
+}
+
+func (m ChannelMedia) String() string {
+	return channelMediaNames[m]
+}
+
+func (l ChannelLocation) String() string {
+	return channelLocationNames[l]
+}
+
+func (p ChannelPresence) String() string {
+	return channelPresenceNames[p]
+}
+
+// MerchantChannel is a decoded Merchant Channel (ID "62.11").
+type MerchantChannel struct {
+	Media    ChannelMedia
+	Location ChannelLocation
+	Presence ChannelPresence
+}
+
+// ParseMerchantChannel parses v, the value of a Merchant Channel, e.g. "000"
+// for a merchant sticker at the merchant premises with an attended POI.
+func ParseMerchantChannel(v string) (MerchantChannel, error) {
+	if len(v) != 3 {
+		return MerchantChannel{}, fmt.Errorf("merchant channel should be 3 characters, merchant channel: %s: %w", v, ErrInvalidFormat)
+	}
+	m := MerchantChannel{
+		Media:    ChannelMedia(v[0]),
+		Location: ChannelLocation(v[1]),
+		Presence: ChannelPresence(v[2]),
+	}
+	if m.Media.String() == "" || m.Location.String() == "" || m.Presence.String() == "" {
+		return MerchantChannel{}, fmt.Errorf("merchant channel invalid, merchant channel: %s: %w", v, ErrInvalidFormat)
+	}
+	return m, nil
+}
+
+// Value returns m as the value of a Merchant Channel.
+func (m MerchantChannel) Value() string {
+	return string([]byte{byte(m.Media), byte(m.Location), byte(m.Presence)})
+}
+
+// String describes m, e.g. "print/merchant sticker, at merchant premises,
+// attended POI".
+func (m MerchantChannel) String() string {
+	return strings.Join([]string{m.Media.String(), m.Location.String(), m.Presence.String()}, ", ")
+}
+
+// Channel returns the decoded Merchant Channel of s.
+func (s *AdditionalDataFieldTemplate) Channel() (MerchantChannel, error) {
+	return ParseMerchantChannel(s.MerchantChannel.Value)
+}
+
+// SetChannel sets the Merchant Channel of s to m.
+func (s *AdditionalDataFieldTemplate) SetChannel(m MerchantChannel) {
+	s.SetMerchantChannel(m.Value())
+}
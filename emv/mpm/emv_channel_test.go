@@ -0,0 +1,74 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseMerchantChannel(t *testing.T) {
+	tests := []struct {
+		v        string
+		want     MerchantChannel
+		wantDesc string
+		wantErr  error
+	}{
+		{
+			v:        "000",
+			want:     MerchantChannel{Media: ChannelMediaPrintSticker, Location: ChannelLocationAtPremises, Presence: ChannelPresenceAttended},
+			wantDesc: "print/merchant sticker, at merchant premises, attended POI",
+		},
+		{
+			v:        "621",
+			want:     MerchantChannel{Media: ChannelMediaScreenApp, Location: ChannelLocationRemoteCommerce, Presence: ChannelPresenceUnattended},
+			wantDesc: "screen/app, remote commerce, unattended",
+		},
+		{v: "800", wantErr: ErrInvalidFormat},
+		{v: "040", wantErr: ErrInvalidFormat},
+		{v: "00", wantErr: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			got, err := ParseMerchantChannel(tt.v)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("ParseMerchantChannel() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMerchantChannel() = %v, want %v", got, tt.want)
+			}
+			if err == nil && (got.String() != tt.wantDesc || got.Value() != tt.v) {
+				t.Errorf("MerchantChannel = %v, %v, want %v, %v", got.String(), got.Value(), tt.wantDesc, tt.v)
+			}
+		})
+	}
+}
+
+func TestEMVQR_ValidateAll_MerchantChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		want    []Violation
+	}{
+		{name: "valid", channel: "421"},
+		{
+			name:    "invalid",
+			channel: "480",
+			want: []Violation{
+				{Path: "62.11", Field: "MerchantChannel", Rule: RuleValue, Severity: SeverityError, Value: "480", Message: "MerchantChannel should be a media (0-7), a location (0-3) and a presence (0-3), MerchantChannel: 480"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			a := new(AdditionalDataFieldTemplate)
+			a.SetMerchantTaxID("TAX1234567")
+			a.SetMerchantChannel(tt.channel)
+			c.SetAdditionalDataFieldTemplate(a)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
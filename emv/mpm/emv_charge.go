@@ -0,0 +1,119 @@
+package mpm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChargeOptions are the amounts entered by the consumer, written like a
+// Transaction Amount (ID "54") value, e.g. "12.50".
+type ChargeOptions struct {
+	// Amount is the amount entered for a QR code without a Transaction
+	// Amount. It must be empty if the QR code has one.
+	Amount string
+	// Tip is the tip entered for a QR code that prompts for one (Tip or
+	// Convenience Indicator "01"). It must be empty otherwise.
+	Tip string
+	// Rounding rounds a percentage fee and the entered amounts to the minor
+	// units of the Transaction Currency, half up by default. With RoundExact
+	// an amount that does not fit is an error.
+	Rounding RoundingMode
+}
+
+// Charge is the breakdown of the amount charged to the consumer, in minor
+// units of Currency.
+type Charge struct {
+	Currency Currency
+	Base     int64 // the Transaction Amount, or the amount entered
+	Tip      int64 // the tip entered
+	Fee      int64 // the convenience fee, fixed or percentage of Base
+	Total    int64 // Base + Tip + Fee
+}
+
+// Charge computes the amount charged to the consumer for c: the Transaction
+// Amount, or opts.Amount, plus the tip or convenience fee of the Tip or
+// Convenience Indicator (ID "55").
+func (c *EMVQR) Charge(opts ChargeOptions) (*Charge, error) {
+	r := &ValidationReport{}
+	r.convenience(c)
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	if c.TransactionCurrency.Value == "" {
+		return nil, &ErrMandatoryMissing{Tag: IDTransactionCurrency.String()}
+	}
+	cur, ok := c.Currency()
+	if !ok {
+		return nil, fmt.Errorf("currency: %s: %w", c.TransactionCurrency.Value, ErrUnknownCurrency)
+	}
+	ch := &Charge{Currency: cur}
+	var err error
+	switch {
+	case c.TransactionAmount.Value != "" && opts.Amount != "":
+		return nil, fmt.Errorf("amount is set by the QR code, amount: %s: %w", opts.Amount, ErrInvalidFormat)
+	case c.TransactionAmount.Value != "":
+		ch.Base, err = ParseAmount(c.TransactionAmount.Value, cur.Code, RoundExact)
+	case opts.Amount != "":
+		ch.Base, err = ParseAmount(opts.Amount, cur.Code, opts.Rounding)
+	default:
+		return nil, &ErrMandatoryMissing{Tag: IDTransactionAmount.String()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	indicator := c.TipOrConvenienceIndicator.Value
+	if opts.Tip != "" {
+		if indicator != TipOrConvenienceIndicatorPrompt {
+			return nil, fmt.Errorf("tip is not prompted for, TipOrConvenienceIndicator: %s: %w", indicator, ErrInvalidFormat)
+		}
+		if ch.Tip, err = ParseAmount(opts.Tip, cur.Code, opts.Rounding); err != nil {
+			return nil, err
+		}
+	}
+	switch indicator {
+	case TipOrConvenienceIndicatorFixed:
+		ch.Fee, err = ParseAmount(c.ValueOfConvenienceFeeFixed.Value, cur.Code, RoundExact)
+	case TipOrConvenienceIndicatorPercentage:
+		ch.Fee, err = percentageOf(ch.Base, c.ValueOfConvenienceFeePercentage.Value, opts.Rounding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ch.Total = ch.Base + ch.Tip + ch.Fee
+	return ch, nil
+}
+
+// percentageOf returns percentage, the value of a Value of Convenience Fee
+// Percentage (ID "57"), of minor, rounded with mode.
+func percentageOf(minor int64, percentage string, mode RoundingMode) (int64, error) {
+	if !percentagePattern.MatchString(percentage) {
+		return 0, fmt.Errorf("percentage should be between \"00.01\" and \"99.99\", percentage: %s: %w", percentage, ErrInvalidFormat)
+	}
+	whole, fraction := percentage, ""
+	if i := strings.IndexByte(percentage, '.'); i >= 0 {
+		whole, fraction = percentage[:i], percentage[i+1:]
+	}
+	hundredths, _ := strconv.ParseInt(whole+fraction+strings.Repeat("0", 2-len(fraction)), 10, 64)
+	const den = 100 * 100
+	q, rem := minor*hundredths/den, minor*hundredths%den
+	if rem == 0 {
+		return q, nil
+	}
+	switch mode.resolved() {
+	case RoundDown:
+	case RoundUp:
+		q++
+	case RoundHalfUp:
+		if 2*rem >= den {
+			q++
+		}
+	case RoundHalfEven:
+		if 2*rem > den || (2*rem == den && q%2 == 1) {
+			q++
+		}
+	default:
+		return 0, fmt.Errorf("fee is not a whole number of minor units, percentage: %s: %w", percentage, ErrInvalidFormat)
+	}
+	return q, nil
+}
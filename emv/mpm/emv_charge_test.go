@@ -0,0 +1,184 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEMVQR_Charge(t *testing.T) {
+	cny, _ := LookupCurrency("156")
+	tests := []struct {
+		name    string
+		modify  func(c *EMVQR)
+		opts    ChargeOptions
+		want    *Charge
+		wantErr error
+	}{
+		{
+			name:   "transaction amount",
+			modify: func(c *EMVQR) {},
+			want:   &Charge{Currency: cny, Base: 2372, Total: 2372},
+		},
+		{
+			name: "amount entered",
+			modify: func(c *EMVQR) {
+				c.TransactionAmount = TLV{}
+			},
+			opts: ChargeOptions{Amount: "100"},
+			want: &Charge{Currency: cny, Base: 10000, Total: 10000},
+		},
+		{
+			name: "tip",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorPrompt)
+			},
+			opts: ChargeOptions{Tip: "1.28"},
+			want: &Charge{Currency: cny, Base: 2372, Tip: 128, Total: 2500},
+		},
+		{
+			name: "fixed fee",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorFixed)
+				c.SetValueOfConvenienceFeeFixed("1.50")
+			},
+			want: &Charge{Currency: cny, Base: 2372, Fee: 150, Total: 2522},
+		},
+		{
+			name: "percentage fee",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorPercentage)
+				c.SetValueOfConvenienceFeePercentage("3.5")
+			},
+			opts: ChargeOptions{Rounding: RoundHalfUp},
+			want: &Charge{Currency: cny, Base: 2372, Fee: 83, Total: 2455},
+		},
+		{
+			name: "percentage fee rounded up",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorPercentage)
+				c.SetValueOfConvenienceFeePercentage("3.5")
+			},
+			opts: ChargeOptions{Rounding: RoundUp},
+			want: &Charge{Currency: cny, Base: 2372, Fee: 84, Total: 2456},
+		},
+		{
+			name: "percentage fee rounded by default",
+			modify: func(c *EMVQR) {
+				c.SetTransactionAmount("10.01")
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorPercentage)
+				c.SetValueOfConvenienceFeePercentage("3")
+			},
+			want: &Charge{Currency: cny, Base: 1001, Fee: 30, Total: 1031},
+		},
+		{
+			name: "percentage fee not exact",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorPercentage)
+				c.SetValueOfConvenienceFeePercentage("3.5")
+			},
+			opts:    ChargeOptions{Rounding: RoundExact},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "amount entered for a transaction amount",
+			modify:  func(c *EMVQR) {},
+			opts:    ChargeOptions{Amount: "100"},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name: "amount missing",
+			modify: func(c *EMVQR) {
+				c.TransactionAmount = TLV{}
+			},
+			wantErr: &ErrMandatoryMissing{Tag: "54"},
+		},
+		{
+			name:    "tip not prompted for",
+			modify:  func(c *EMVQR) {},
+			opts:    ChargeOptions{Tip: "1"},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name: "fixed fee without indicator",
+			modify: func(c *EMVQR) {
+				c.SetValueOfConvenienceFeeFixed("1.50")
+			},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name: "fixed fee missing",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator(TipOrConvenienceIndicatorFixed)
+			},
+			wantErr: &ErrMandatoryMissing{Tag: "56"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got, err := c.Charge(tt.opts)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("EMVQR.Charge() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EMVQR.Charge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEMVQR_ValidateAll_Convenience(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		want   []Violation
+	}{
+		{
+			name: "tip",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("01")
+			},
+		},
+		{
+			name: "unknown indicator",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("04")
+			},
+			want: []Violation{
+				{Path: "55", Field: "TipOrConvenienceIndicator", Rule: RuleValue, Severity: SeverityError, Value: "04", Message: "TipOrConvenienceIndicator should be \"01\", \"02\" or \"03\", TipOrConvenienceIndicator: 04"},
+			},
+		},
+		{
+			name: "percentage missing",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("03")
+			},
+			want: []Violation{
+				{Path: "57", Field: "ValueOfConvenienceFeePercentage", Rule: RuleMandatory, Severity: SeverityError, Message: "ValueOfConvenienceFeePercentage is mandatory"},
+			},
+		},
+		{
+			name: "fixed fee with percentage indicator",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("03")
+				c.SetValueOfConvenienceFeeFixed("1")
+				c.SetValueOfConvenienceFeePercentage("3")
+			},
+			want: []Violation{
+				{Path: "56", Field: "ValueOfConvenienceFeeFixed", Rule: RuleConditional, Severity: SeverityError, Value: "1", Message: "ValueOfConvenienceFeeFixed should be present only if TipOrConvenienceIndicator is \"02\", TipOrConvenienceIndicator: 03"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package mpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConsumerData is a set of the data that an Additional Consumer Data
+// Request (ID "62.09") asks the consumer for.
+type ConsumerData uint8
+
+// const ...
+const (
+	ConsumerDataAddress ConsumerData = 1 << iota // "A"
+	ConsumerDataMobile                           // "M"
+	ConsumerDataEmail                            // "E"
+)
+
+var consumerDataLetters = []struct {
+	data   ConsumerData
+	letter byte
+}{
+	{ConsumerDataAddress, 'A'},
+	{ConsumerDataMobile, 'M'},
+	{ConsumerDataEmail, 'E'},
+}
+
+// ParseConsumerDataRequest parses v, the value of an Additional Consumer Data
+// Request, e.g. "ME" for the mobile number and the email address.
+func ParseConsumerDataRequest(v string) (ConsumerData, error) {
+	if v == "" {
+		return 0, fmt.Errorf("consumer data request is empty: %w", ErrInvalidFormat)
+	}
+	var d ConsumerData
+	for i := 0; i < len(v); i++ {
+		f := ConsumerData(0)
+		for _, l := range consumerDataLetters {
+			if l.letter == v[i] {
+				f = l.data
+			}
+		}
+		if f == 0 {
+			return 0, fmt.Errorf("consumer data request has unknown letter %q, consumer data request: %s: %w", v[i], v, ErrInvalidFormat)
+		}
+		if d.Has(f) {
+			return 0, fmt.Errorf("consumer data request repeats letter %q, consumer data request: %s: %w", v[i], v, ErrInvalidFormat)
+		}
+		d |= f
+	}
+	return d, nil
+}
+
+// Has reports whether d asks for all of f.
+func (d ConsumerData) Has(f ConsumerData) bool {
+	return d&f == f
+}
+
+// String returns d as the value of an Additional Consumer Data Request, in
+// the order "A", "M", "E".
+func (d ConsumerData) String() string {
+	var b strings.Builder
+	for _, l := range consumerDataLetters {
+		if d.Has(l.data) {
+			b.WriteByte(l.letter)
+		}
+	}
+	return b.String()
+}
+
+// ConsumerDataRequest returns the decoded Additional Consumer Data Request
+// of s.
+func (s *AdditionalDataFieldTemplate) ConsumerDataRequest() (ConsumerData, error) {
+	return ParseConsumerDataRequest(s.AdditionalConsumerDataRequest.Value)
+}
+
+// SetConsumerDataRequest sets the Additional Consumer Data Request of s to d.
+func (s *AdditionalDataFieldTemplate) SetConsumerDataRequest(d ConsumerData) {
+	s.SetAdditionalConsumerDataRequest(d.String())
+}
+
+// ConsumerDataResponse is the data a consumer returns for an Additional
+// Consumer Data Request.
+type ConsumerDataResponse struct {
+	Address string `json:"address,omitempty"`
+	Mobile  string `json:"mobile,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// Validate checks that r has exactly the data that request asks for.
+func (r ConsumerDataResponse) Validate(request ConsumerData) error {
+	fields := []struct {
+		data  ConsumerData
+		name  string
+		value string
+	}{
+		{ConsumerDataAddress, "address", r.Address},
+		{ConsumerDataMobile, "mobile", r.Mobile},
+		{ConsumerDataEmail, "email", r.Email},
+	}
+	for _, f := range fields {
+		if request.Has(f.data) && f.value == "" {
+			return fmt.Errorf("consumer data response has no %s: %w", f.name, &ErrMandatoryMissing{Tag: tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDAdditionalConsumerDataRequest)})
+		}
+		if !request.Has(f.data) && f.value != "" {
+			return fmt.Errorf("consumer data response has an unrequested %s: %w", f.name, ErrInvalidFormat)
+		}
+	}
+	return nil
+}
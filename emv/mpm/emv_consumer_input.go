@@ -0,0 +1,117 @@
+package mpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConsumerInput is the value of a data object that the consumer app must
+// prompt the consumer for.
+const ConsumerInput = "***"
+
+type consumerInputField struct {
+	id  ID
+	tlv TLV
+	set func(string)
+}
+
+// consumerInputFields returns the data objects of s that may hold
+// ConsumerInput: IDs "01" to "08".
+func (s *AdditionalDataFieldTemplate) consumerInputFields() []consumerInputField {
+	return []consumerInputField{
+		{AdditionalIDBillNumber, s.BillNumber, s.SetBillNumber},
+		{AdditionalIDMobileNumber, s.MobileNumber, s.SetMobileNumber},
+		{AdditionalIDStoreLabel, s.StoreLabel, s.SetStoreLabel},
+		{AdditionalIDLoyaltyNumber, s.LoyaltyNumber, s.SetLoyaltyNumber},
+		{AdditionalIDReferenceLabel, s.ReferenceLabel, s.SetReferenceLabel},
+		{AdditionalIDCustomerLabel, s.CustomerLabel, s.SetCustomerLabel},
+		{AdditionalIDTerminalLabel, s.TerminalLabel, s.SetTerminalLabel},
+		{AdditionalIDPurposeTransaction, s.PurposeTransaction, s.SetPurposeTransaction},
+	}
+}
+
+// consumerInputAllowed reports whether the data object at path may hold
+// ConsumerInput.
+func consumerInputAllowed(path string) bool {
+	prefix := IDAdditionalDataFieldTemplate.String() + "."
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	within, err := ID(strings.TrimPrefix(path, prefix)).Between(AdditionalIDBillNumber, AdditionalIDPurposeTransaction)
+	return err == nil && within
+}
+
+// ConsumerInputFields returns the IDs of the data objects of s that hold
+// ConsumerInput.
+func (s *AdditionalDataFieldTemplate) ConsumerInputFields() []ID {
+	if s == nil {
+		return nil
+	}
+	var ids []ID
+	for _, f := range s.consumerInputFields() {
+		if f.tlv.Value == ConsumerInput {
+			ids = append(ids, f.id)
+		}
+	}
+	return ids
+}
+
+// FillConsumerInput returns a copy of s with the data objects that hold
+// ConsumerInput set to values, keyed by ID. Each of them needs a value.
+func (s *AdditionalDataFieldTemplate) FillConsumerInput(values map[ID]string) (*AdditionalDataFieldTemplate, error) {
+	n := *s
+	if err := n.fillConsumerInput(values); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (s *AdditionalDataFieldTemplate) fillConsumerInput(values map[ID]string) error {
+	prompted := make(map[ID]bool)
+	for _, f := range s.consumerInputFields() {
+		if f.tlv.Value != ConsumerInput {
+			continue
+		}
+		prompted[f.id] = true
+		v := values[f.id]
+		if v == "" || v == ConsumerInput {
+			return &ErrMandatoryMissing{Tag: tagPath(IDAdditionalDataFieldTemplate.String(), f.id)}
+		}
+		f.set(v)
+	}
+	for id := range values {
+		if !prompted[id] {
+			return fmt.Errorf("data object does not prompt for consumer input. path: %s: %w", tagPath(IDAdditionalDataFieldTemplate.String(), id), ErrInvalidFormat)
+		}
+	}
+	return nil
+}
+
+// FillConsumerInput returns a copy of c with the Additional Data Field
+// Template data objects that hold ConsumerInput set to values, keyed by ID.
+// c is not modified.
+func (c *EMVQR) FillConsumerInput(values map[ID]string) (*EMVQR, error) {
+	a := c.AdditionalDataFieldTemplate
+	if a == nil {
+		a = &AdditionalDataFieldTemplate{}
+	}
+	filled, err := a.FillConsumerInput(values)
+	if err != nil {
+		return nil, err
+	}
+	t, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if c.AdditionalDataFieldTemplate != nil {
+		if err := t.Set(IDAdditionalDataFieldTemplate.String(), filled.String()[4:]); err != nil {
+			return nil, err
+		}
+	}
+	n, err := t.EMVQR()
+	if err != nil {
+		return nil, err
+	}
+	n.SetOrder(c.order)
+	return n, nil
+}
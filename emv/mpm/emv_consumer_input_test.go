@@ -0,0 +1,157 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAdditionalDataFieldTemplate_ConsumerInputFields(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := c.AdditionalDataFieldTemplate.ConsumerInputFields()
+	want := []ID{AdditionalIDCustomerLabel}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AdditionalDataFieldTemplate.ConsumerInputFields() = %v, want %v", got, want)
+	}
+}
+
+func TestEMVQR_FillConsumerInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[ID]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "filled",
+			values: map[ID]string{AdditionalIDCustomerLabel: "C42"},
+			want:   "C42",
+		},
+		{
+			name:    "missing",
+			values:  map[ID]string{},
+			wantErr: &ErrMandatoryMissing{Tag: "62.06"},
+		},
+		{
+			name:    "not prompted",
+			values:  map[ID]string{AdditionalIDCustomerLabel: "C42", AdditionalIDBillNumber: "B1"},
+			wantErr: ErrInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Decode(samplePayload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.FillConsumerInput(tt.values)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("EMVQR.FillConsumerInput() error = %v, want %v", err, tt.wantErr)
+			}
+			if c.AdditionalDataFieldTemplate.CustomerLabel.Value != ConsumerInput {
+				t.Errorf("EMVQR.FillConsumerInput() modified c: %v", c.AdditionalDataFieldTemplate.CustomerLabel)
+			}
+			if err != nil {
+				return
+			}
+			if v := got.AdditionalDataFieldTemplate.CustomerLabel.Value; v != tt.want {
+				t.Errorf("EMVQR.FillConsumerInput() CustomerLabel = %v, want %v", v, tt.want)
+			}
+			payload, err := got.GeneratePayload()
+			if err != nil {
+				t.Fatalf("EMVQR.GeneratePayload() error = %v", err)
+			}
+			if _, err := Decode(payload); err != nil {
+				t.Errorf("Decode() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestEMVQR_ValidateAll_ConsumerInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		want   []Violation
+	}{
+		{
+			name: "reference label",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetReferenceLabel(ConsumerInput)
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+		},
+		{
+			name: "merchant name",
+			modify: func(c *EMVQR) {
+				c.SetMerchantName(ConsumerInput)
+			},
+			want: []Violation{
+				{Path: "59", Field: "MerchantName", Rule: RuleValue, Severity: SeverityError, Value: "***", Message: "MerchantName should not prompt for consumer input, MerchantName: ***"},
+			},
+		},
+		{
+			name: "merchant tax id",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetMerchantTaxID(ConsumerInput)
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			want: []Violation{
+				{Path: "62.10", Field: "MerchantTaxID", Rule: RuleValue, Severity: SeverityError, Value: "***", Message: "MerchantTaxID should not prompt for consumer input, MerchantTaxID: ***"},
+			},
+		},
+		{
+			name: "payment network specific",
+			modify: func(c *EMVQR) {
+				m := new(MerchantAccountInformation)
+				m.SetGloballyUniqueIdentifier("D15600000000")
+				m.AddPaymentNetworkSpecific("01", ConsumerInput)
+				c.AddMerchantAccountInformation("29", m)
+			},
+			want: []Violation{
+				{Path: "29.01", Field: "PaymentNetworkSpecific", Rule: RuleValue, Severity: SeverityError, Value: "***", Message: "PaymentNetworkSpecific should not prompt for consumer input, PaymentNetworkSpecific: ***"},
+			},
+		},
+		{
+			name: "unreserved template",
+			modify: func(c *EMVQR) {
+				u := new(UnreservedTemplate)
+				u.SetGloballyUniqueIdentifier("com.example")
+				u.AddContextSpecificData("01", ConsumerInput)
+				c.AddUnreservedTemplates("80", u)
+			},
+			want: []Violation{
+				{Path: "80.01", Field: "ContextSpecificData", Rule: RuleValue, Severity: SeverityError, Value: "***", Message: "ContextSpecificData should not prompt for consumer input, ContextSpecificData: ***"},
+			},
+		},
+		{
+			name: "payment system specific",
+			modify: func(c *EMVQR) {
+				p := new(PaymentSystemSpecificTemplate)
+				p.SetGloballyUniqueIdentifier("com.example")
+				p.AddPaymentSystemSpecific("01", ConsumerInput)
+				a := new(AdditionalDataFieldTemplate)
+				a.AddPaymentSystemSpecificTemplate("50", p)
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			want: []Violation{
+				{Path: "62.50.01", Field: "PaymentSystemSpecific", Rule: RuleValue, Severity: SeverityError, Value: "***", Message: "PaymentSystemSpecific should not prompt for consumer input, PaymentSystemSpecific: ***"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
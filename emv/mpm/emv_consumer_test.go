@@ -0,0 +1,84 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseConsumerDataRequest(t *testing.T) {
+	tests := []struct {
+		v       string
+		want    ConsumerData
+		wantStr string
+		wantErr error
+	}{
+		{v: "ME", want: ConsumerDataMobile | ConsumerDataEmail, wantStr: "ME"},
+		{v: "EMA", want: ConsumerDataAddress | ConsumerDataMobile | ConsumerDataEmail, wantStr: "AME"},
+		{v: "A", want: ConsumerDataAddress, wantStr: "A"},
+		{v: "MM", wantErr: ErrInvalidFormat},
+		{v: "MX", wantErr: ErrInvalidFormat},
+		{v: "", wantErr: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			got, err := ParseConsumerDataRequest(tt.v)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("ParseConsumerDataRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want || got.String() != tt.wantStr {
+				t.Errorf("ParseConsumerDataRequest() = %v (%s), want %v (%s)", uint8(got), got, uint8(tt.want), tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestConsumerDataResponse_Validate(t *testing.T) {
+	request := ConsumerDataMobile | ConsumerDataEmail
+	tests := []struct {
+		name    string
+		r       ConsumerDataResponse
+		wantErr error
+	}{
+		{name: "complete", r: ConsumerDataResponse{Mobile: "+66812345678", Email: "a@example.com"}},
+		{name: "missing", r: ConsumerDataResponse{Mobile: "+66812345678"}, wantErr: &ErrMandatoryMissing{}},
+		{name: "unrequested", r: ConsumerDataResponse{Mobile: "+66812345678", Email: "a@example.com", Address: "Bangkok"}, wantErr: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.Validate(request)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("ConsumerDataResponse.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEMVQR_ValidateAll_ConsumerDataRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		v    string
+		want []Violation
+	}{
+		{name: "valid", v: "AME"},
+		{
+			name: "repeated",
+			v:    "MEM",
+			want: []Violation{
+				{Path: "62.09", Field: "AdditionalConsumerDataRequest", Rule: RuleValue, Severity: SeverityError, Value: "MEM", Message: "AdditionalConsumerDataRequest should be a combination of \"A\", \"M\" and \"E\" without repeats, AdditionalConsumerDataRequest: MEM"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			a := new(AdditionalDataFieldTemplate)
+			a.SetAdditionalConsumerDataRequest(tt.v)
+			c.SetAdditionalDataFieldTemplate(a)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
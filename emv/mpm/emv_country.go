@@ -0,0 +1,317 @@
+package mpm
+
+import (
+	"strings"
+)
+
+// Country is an ISO 3166-1 country.
+type Country struct {
+	Code       string   // alpha-2 code, the value of ID "58", e.g. "TH"
+	Name       string   // short name, e.g. "Thailand"
+	Currencies []string // ISO 4217 numeric codes of the currencies in usual use
+}
+
+// countries is the ISO 3166-1 list of officially assigned alpha-2 codes.
+var countries = []Country{
+	{Code: "AD", Name: "Andorra", Currencies: []string{"978"}},
+	{Code: "AE", Name: "United Arab Emirates", Currencies: []string{"784"}},
+	{Code: "AF", Name: "Afghanistan", Currencies: []string{"971"}},
+	{Code: "AG", Name: "Antigua and Barbuda", Currencies: []string{"951"}},
+	{Code: "AI", Name: "Anguilla", Currencies: []string{"951"}},
+	{Code: "AL", Name: "Albania", Currencies: []string{"008"}},
+	{Code: "AM", Name: "Armenia", Currencies: []string{"051"}},
+	{Code: "AO", Name: "Angola", Currencies: []string{"973"}},
+	{Code: "AQ", Name: "Antarctica", Currencies: nil},
+	{Code: "AR", Name: "Argentina", Currencies: []string{"032"}},
+	{Code: "AS", Name: "American Samoa", Currencies: []string{"840"}},
+	{Code: "AT", Name: "Austria", Currencies: []string{"978"}},
+	{Code: "AU", Name: "Australia", Currencies: []string{"036"}},
+	{Code: "AW", Name: "Aruba", Currencies: []string{"533"}},
+	{Code: "AX", Name: "Åland Islands", Currencies: []string{"978"}},
+	{Code: "AZ", Name: "Azerbaijan", Currencies: []string{"944"}},
+	{Code: "BA", Name: "Bosnia and Herzegovina", Currencies: []string{"977"}},
+	{Code: "BB", Name: "Barbados", Currencies: []string{"052"}},
+	{Code: "BD", Name: "Bangladesh", Currencies: []string{"050"}},
+	{Code: "BE", Name: "Belgium", Currencies: []string{"978"}},
+	{Code: "BF", Name: "Burkina Faso", Currencies: []string{"952"}},
+	{Code: "BG", Name: "Bulgaria", Currencies: []string{"975", "978"}},
+	{Code: "BH", Name: "Bahrain", Currencies: []string{"048"}},
+	{Code: "BI", Name: "Burundi", Currencies: []string{"108"}},
+	{Code: "BJ", Name: "Benin", Currencies: []string{"952"}},
+	{Code: "BL", Name: "Saint Barthélemy", Currencies: []string{"978"}},
+	{Code: "BM", Name: "Bermuda", Currencies: []string{"060"}},
+	{Code: "BN", Name: "Brunei Darussalam", Currencies: []string{"096"}},
+	{Code: "BO", Name: "Bolivia", Currencies: []string{"068", "984"}},
+	{Code: "BQ", Name: "Bonaire, Sint Eustatius and Saba", Currencies: []string{"840"}},
+	{Code: "BR", Name: "Brazil", Currencies: []string{"986"}},
+	{Code: "BS", Name: "Bahamas", Currencies: []string{"044"}},
+	{Code: "BT", Name: "Bhutan", Currencies: []string{"064", "356"}},
+	{Code: "BV", Name: "Bouvet Island", Currencies: []string{"578"}},
+	{Code: "BW", Name: "Botswana", Currencies: []string{"072"}},
+	{Code: "BY", Name: "Belarus", Currencies: []string{"933"}},
+	{Code: "BZ", Name: "Belize", Currencies: []string{"084"}},
+	{Code: "CA", Name: "Canada", Currencies: []string{"124"}},
+	{Code: "CC", Name: "Cocos (Keeling) Islands", Currencies: []string{"036"}},
+	{Code: "CD", Name: "Congo, Democratic Republic of the", Currencies: []string{"976"}},
+	{Code: "CF", Name: "Central African Republic", Currencies: []string{"950"}},
+	{Code: "CG", Name: "Congo", Currencies: []string{"950"}},
+	{Code: "CH", Name: "Switzerland", Currencies: []string{"756", "947", "948"}},
+	{Code: "CI", Name: "Côte d'Ivoire", Currencies: []string{"952"}},
+	{Code: "CK", Name: "Cook Islands", Currencies: []string{"554"}},
+	{Code: "CL", Name: "Chile", Currencies: []string{"152", "990"}},
+	{Code: "CM", Name: "Cameroon", Currencies: []string{"950"}},
+	{Code: "CN", Name: "China", Currencies: []string{"156"}},
+	{Code: "CO", Name: "Colombia", Currencies: []string{"170", "970"}},
+	{Code: "CR", Name: "Costa Rica", Currencies: []string{"188"}},
+	{Code: "CU", Name: "Cuba", Currencies: []string{"192"}},
+	{Code: "CV", Name: "Cabo Verde", Currencies: []string{"132"}},
+	{Code: "CW", Name: "Curaçao", Currencies: []string{"532"}},
+	{Code: "CX", Name: "Christmas Island", Currencies: []string{"036"}},
+	{Code: "CY", Name: "Cyprus", Currencies: []string{"978"}},
+	{Code: "CZ", Name: "Czechia", Currencies: []string{"203"}},
+	{Code: "DE", Name: "Germany", Currencies: []string{"978"}},
+	{Code: "DJ", Name: "Djibouti", Currencies: []string{"262"}},
+	{Code: "DK", Name: "Denmark", Currencies: []string{"208"}},
+	{Code: "DM", Name: "Dominica", Currencies: []string{"951"}},
+	{Code: "DO", Name: "Dominican Republic", Currencies: []string{"214"}},
+	{Code: "DZ", Name: "Algeria", Currencies: []string{"012"}},
+	{Code: "EC", Name: "Ecuador", Currencies: []string{"840"}},
+	{Code: "EE", Name: "Estonia", Currencies: []string{"978"}},
+	{Code: "EG", Name: "Egypt", Currencies: []string{"818"}},
+	{Code: "EH", Name: "Western Sahara", Currencies: []string{"504"}},
+	{Code: "ER", Name: "Eritrea", Currencies: []string{"232"}},
+	{Code: "ES", Name: "Spain", Currencies: []string{"978"}},
+	{Code: "ET", Name: "Ethiopia", Currencies: []string{"230"}},
+	{Code: "FI", Name: "Finland", Currencies: []string{"978"}},
+	{Code: "FJ", Name: "Fiji", Currencies: []string{"242"}},
+	{Code: "FK", Name: "Falkland Islands (Malvinas)", Currencies: []string{"238"}},
+	{Code: "FM", Name: "Micronesia", Currencies: []string{"840"}},
+	{Code: "FO", Name: "Faroe Islands", Currencies: []string{"208"}},
+	{Code: "FR", Name: "France", Currencies: []string{"978"}},
+	{Code: "GA", Name: "Gabon", Currencies: []string{"950"}},
+	{Code: "GB", Name: "United Kingdom", Currencies: []string{"826"}},
+	{Code: "GD", Name: "Grenada", Currencies: []string{"951"}},
+	{Code: "GE", Name: "Georgia", Currencies: []string{"981"}},
+	{Code: "GF", Name: "French Guiana", Currencies: []string{"978"}},
+	{Code: "GG", Name: "Guernsey", Currencies: []string{"826"}},
+	{Code: "GH", Name: "Ghana", Currencies: []string{"936"}},
+	{Code: "GI", Name: "Gibraltar", Currencies: []string{"292"}},
+	{Code: "GL", Name: "Greenland", Currencies: []string{"208"}},
+	{Code: "GM", Name: "Gambia", Currencies: []string{"270"}},
+	{Code: "GN", Name: "Guinea", Currencies: []string{"324"}},
+	{Code: "GP", Name: "Guadeloupe", Currencies: []string{"978"}},
+	{Code: "GQ", Name: "Equatorial Guinea", Currencies: []string{"950"}},
+	{Code: "GR", Name: "Greece", Currencies: []string{"978"}},
+	{Code: "GS", Name: "South Georgia and the South Sandwich Islands", Currencies: []string{"826"}},
+	{Code: "GT", Name: "Guatemala", Currencies: []string{"320"}},
+	{Code: "GU", Name: "Guam", Currencies: []string{"840"}},
+	{Code: "GW", Name: "Guinea-Bissau", Currencies: []string{"952"}},
+	{Code: "GY", Name: "Guyana", Currencies: []string{"328"}},
+	{Code: "HK", Name: "Hong Kong", Currencies: []string{"344"}},
+	{Code: "HM", Name: "Heard Island and McDonald Islands", Currencies: []string{"036"}},
+	{Code: "HN", Name: "Honduras", Currencies: []string{"340"}},
+	{Code: "HR", Name: "Croatia", Currencies: []string{"978"}},
+	{Code: "HT", Name: "Haiti", Currencies: []string{"332", "840"}},
+	{Code: "HU", Name: "Hungary", Currencies: []string{"348"}},
+	{Code: "ID", Name: "Indonesia", Currencies: []string{"360"}},
+	{Code: "IE", Name: "Ireland", Currencies: []string{"978"}},
+	{Code: "IL", Name: "Israel", Currencies: []string{"376"}},
+	{Code: "IM", Name: "Isle of Man", Currencies: []string{"826"}},
+	{Code: "IN", Name: "India", Currencies: []string{"356"}},
+	{Code: "IO", Name: "British Indian Ocean Territory", Currencies: []string{"840"}},
+	{Code: "IQ", Name: "Iraq", Currencies: []string{"368"}},
+	{Code: "IR", Name: "Iran", Currencies: []string{"364"}},
+	{Code: "IS", Name: "Iceland", Currencies: []string{"352"}},
+	{Code: "IT", Name: "Italy", Currencies: []string{"978"}},
+	{Code: "JE", Name: "Jersey", Currencies: []string{"826"}},
+	{Code: "JM", Name: "Jamaica", Currencies: []string{"388"}},
+	{Code: "JO", Name: "Jordan", Currencies: []string{"400"}},
+	{Code: "JP", Name: "Japan", Currencies: []string{"392"}},
+	{Code: "KE", Name: "Kenya", Currencies: []string{"404"}},
+	{Code: "KG", Name: "Kyrgyzstan", Currencies: []string{"417"}},
+	{Code: "KH", Name: "Cambodia", Currencies: []string{"116", "840"}},
+	{Code: "KI", Name: "Kiribati", Currencies: []string{"036"}},
+	{Code: "KM", Name: "Comoros", Currencies: []string{"174"}},
+	{Code: "KN", Name: "Saint Kitts and Nevis", Currencies: []string{"951"}},
+	{Code: "KP", Name: "Korea, Democratic People's Republic of", Currencies: []string{"408"}},
+	{Code: "KR", Name: "Korea, Republic of", Currencies: []string{"410"}},
+	{Code: "KW", Name: "Kuwait", Currencies: []string{"414"}},
+	{Code: "KY", Name: "Cayman Islands", Currencies: []string{"136"}},
+	{Code: "KZ", Name: "Kazakhstan", Currencies: []string{"398"}},
+	{Code: "LA", Name: "Lao People's Democratic Republic", Currencies: []string{"418"}},
+	{Code: "LB", Name: "Lebanon", Currencies: []string{"422"}},
+	{Code: "LC", Name: "Saint Lucia", Currencies: []string{"951"}},
+	{Code: "LI", Name: "Liechtenstein", Currencies: []string{"756"}},
+	{Code: "LK", Name: "Sri Lanka", Currencies: []string{"144"}},
+	{Code: "LR", Name: "Liberia", Currencies: []string{"430"}},
+	{Code: "LS", Name: "Lesotho", Currencies: []string{"426", "710"}},
+	{Code: "LT", Name: "Lithuania", Currencies: []string{"978"}},
+	{Code: "LU", Name: "Luxembourg", Currencies: []string{"978"}},
+	{Code: "LV", Name: "Latvia", Currencies: []string{"978"}},
+	{Code: "LY", Name: "Libya", Currencies: []string{"434"}},
+	{Code: "MA", Name: "Morocco", Currencies: []string{"504"}},
+	{Code: "MC", Name: "Monaco", Currencies: []string{"978"}},
+	{Code: "MD", Name: "Moldova", Currencies: []string{"498"}},
+	{Code: "ME", Name: "Montenegro", Currencies: []string{"978"}},
+	{Code: "MF", Name: "Saint Martin (French part)", Currencies: []string{"978"}},
+	{Code: "MG", Name: "Madagascar", Currencies: []string{"969"}},
+	{Code: "MH", Name: "Marshall Islands", Currencies: []string{"840"}},
+	{Code: "MK", Name: "North Macedonia", Currencies: []string{"807"}},
+	{Code: "ML", Name: "Mali", Currencies: []string{"952"}},
+	{Code: "MM", Name: "Myanmar", Currencies: []string{"104"}},
+	{Code: "MN", Name: "Mongolia", Currencies: []string{"496"}},
+	{Code: "MO", Name: "Macao", Currencies: []string{"446"}},
+	{Code: "MP", Name: "Northern Mariana Islands", Currencies: []string{"840"}},
+	{Code: "MQ", Name: "Martinique", Currencies: []string{"978"}},
+	{Code: "MR", Name: "Mauritania", Currencies: []string{"929"}},
+	{Code: "MS", Name: "Montserrat", Currencies: []string{"951"}},
+	{Code: "MT", Name: "Malta", Currencies: []string{"978"}},
+	{Code: "MU", Name: "Mauritius", Currencies: []string{"480"}},
+	{Code: "MV", Name: "Maldives", Currencies: []string{"462"}},
+	{Code: "MW", Name: "Malawi", Currencies: []string{"454"}},
+	{Code: "MX", Name: "Mexico", Currencies: []string{"484", "979"}},
+	{Code: "MY", Name: "Malaysia", Currencies: []string{"458"}},
+	{Code: "MZ", Name: "Mozambique", Currencies: []string{"943"}},
+	{Code: "NA", Name: "Namibia", Currencies: []string{"516", "710"}},
+	{Code: "NC", Name: "New Caledonia", Currencies: []string{"953"}},
+	{Code: "NE", Name: "Niger", Currencies: []string{"952"}},
+	{Code: "NF", Name: "Norfolk Island", Currencies: []string{"036"}},
+	{Code: "NG", Name: "Nigeria", Currencies: []string{"566"}},
+	{Code: "NI", Name: "Nicaragua", Currencies: []string{"558"}},
+	{Code: "NL", Name: "Netherlands", Currencies: []string{"978"}},
+	{Code: "NO", Name: "Norway", Currencies: []string{"578"}},
+	{Code: "NP", Name: "Nepal", Currencies: []string{"524"}},
+	{Code: "NR", Name: "Nauru", Currencies: []string{"036"}},
+	{Code: "NU", Name: "Niue", Currencies: []string{"554"}},
+	{Code: "NZ", Name: "New Zealand", Currencies: []string{"554"}},
+	{Code: "OM", Name: "Oman", Currencies: []string{"512"}},
+	{Code: "PA", Name: "Panama", Currencies: []string{"590", "840"}},
+	{Code: "PE", Name: "Peru", Currencies: []string{"604"}},
+	{Code: "PF", Name: "French Polynesia", Currencies: []string{"953"}},
+	{Code: "PG", Name: "Papua New Guinea", Currencies: []string{"598"}},
+	{Code: "PH", Name: "Philippines", Currencies: []string{"608"}},
+	{Code: "PK", Name: "Pakistan", Currencies: []string{"586"}},
+	{Code: "PL", Name: "Poland", Currencies: []string{"985"}},
+	{Code: "PM", Name: "Saint Pierre and Miquelon", Currencies: []string{"978"}},
+	{Code: "PN", Name: "Pitcairn", Currencies: []string{"554"}},
+	{Code: "PR", Name: "Puerto Rico", Currencies: []string{"840"}},
+	{Code: "PS", Name: "Palestine, State of", Currencies: []string{"376", "400"}},
+	{Code: "PT", Name: "Portugal", Currencies: []string{"978"}},
+	{Code: "PW", Name: "Palau", Currencies: []string{"840"}},
+	{Code: "PY", Name: "Paraguay", Currencies: []string{"600"}},
+	{Code: "QA", Name: "Qatar", Currencies: []string{"634"}},
+	{Code: "RE", Name: "Réunion", Currencies: []string{"978"}},
+	{Code: "RO", Name: "Romania", Currencies: []string{"946"}},
+	{Code: "RS", Name: "Serbia", Currencies: []string{"941"}},
+	{Code: "RU", Name: "Russian Federation", Currencies: []string{"643"}},
+	{Code: "RW", Name: "Rwanda", Currencies: []string{"646"}},
+	{Code: "SA", Name: "Saudi Arabia", Currencies: []string{"682"}},
+	{Code: "SB", Name: "Solomon Islands", Currencies: []string{"090"}},
+	{Code: "SC", Name: "Seychelles", Currencies: []string{"690"}},
+	{Code: "SD", Name: "Sudan", Currencies: []string{"938"}},
+	{Code: "SE", Name: "Sweden", Currencies: []string{"752"}},
+	{Code: "SG", Name: "Singapore", Currencies: []string{"702"}},
+	{Code: "SH", Name: "Saint Helena, Ascension and Tristan da Cunha", Currencies: []string{"654"}},
+	{Code: "SI", Name: "Slovenia", Currencies: []string{"978"}},
+	{Code: "SJ", Name: "Svalbard and Jan Mayen", Currencies: []string{"578"}},
+	{Code: "SK", Name: "Slovakia", Currencies: []string{"978"}},
+	{Code: "SL", Name: "Sierra Leone", Currencies: []string{"925"}},
+	{Code: "SM", Name: "San Marino", Currencies: []string{"978"}},
+	{Code: "SN", Name: "Senegal", Currencies: []string{"952"}},
+	{Code: "SO", Name: "Somalia", Currencies: []string{"706"}},
+	{Code: "SR", Name: "Suriname", Currencies: []string{"968"}},
+	{Code: "SS", Name: "South Sudan", Currencies: []string{"728"}},
+	{Code: "ST", Name: "Sao Tome and Principe", Currencies: []string{"930"}},
+	{Code: "SV", Name: "El Salvador", Currencies: []string{"222", "840"}},
+	{Code: "SX", Name: "Sint Maarten (Dutch part)", Currencies: []string{"532"}},
+	{Code: "SY", Name: "Syrian Arab Republic", Currencies: []string{"760"}},
+	{Code: "SZ", Name: "Eswatini", Currencies: []string{"748", "710"}},
+	{Code: "TC", Name: "Turks and Caicos Islands", Currencies: []string{"840"}},
+	{Code: "TD", Name: "Chad", Currencies: []string{"950"}},
+	{Code: "TF", Name: "French Southern Territories", Currencies: []string{"978"}},
+	{Code: "TG", Name: "Togo", Currencies: []string{"952"}},
+	{Code: "TH", Name: "Thailand", Currencies: []string{"764"}},
+	{Code: "TJ", Name: "Tajikistan", Currencies: []string{"972"}},
+	{Code: "TK", Name: "Tokelau", Currencies: []string{"554"}},
+	{Code: "TL", Name: "Timor-Leste", Currencies: []string{"840"}},
+	{Code: "TM", Name: "Turkmenistan", Currencies: []string{"934"}},
+	{Code: "TN", Name: "Tunisia", Currencies: []string{"788"}},
+	{Code: "TO", Name: "Tonga", Currencies: []string{"776"}},
+	{Code: "TR", Name: "Türkiye", Currencies: []string{"949"}},
+	{Code: "TT", Name: "Trinidad and Tobago", Currencies: []string{"780"}},
+	{Code: "TV", Name: "Tuvalu", Currencies: []string{"036"}},
+	{Code: "TW", Name: "Taiwan", Currencies: []string{"901"}},
+	{Code: "TZ", Name: "Tanzania", Currencies: []string{"834"}},
+	{Code: "UA", Name: "Ukraine", Currencies: []string{"980"}},
+	{Code: "UG", Name: "Uganda", Currencies: []string{"800"}},
+	{Code: "UM", Name: "United States Minor Outlying Islands", Currencies: []string{"840"}},
+	{Code: "US", Name: "United States of America", Currencies: []string{"840", "997"}},
+	{Code: "UY", Name: "Uruguay", Currencies: []string{"858", "940", "927"}},
+	{Code: "UZ", Name: "Uzbekistan", Currencies: []string{"860"}},
+	{Code: "VA", Name: "Holy See", Currencies: []string{"978"}},
+	{Code: "VC", Name: "Saint Vincent and the Grenadines", Currencies: []string{"951"}},
+	{Code: "VE", Name: "Venezuela", Currencies: []string{"928", "926"}},
+	{Code: "VG", Name: "Virgin Islands (British)", Currencies: []string{"840"}},
+	{Code: "VI", Name: "Virgin Islands (U.S.)", Currencies: []string{"840"}},
+	{Code: "VN", Name: "Viet Nam", Currencies: []string{"704"}},
+	{Code: "VU", Name: "Vanuatu", Currencies: []string{"548"}},
+	{Code: "WF", Name: "Wallis and Futuna", Currencies: []string{"953"}},
+	{Code: "WS", Name: "Samoa", Currencies: []string{"882"}},
+	{Code: "YE", Name: "Yemen", Currencies: []string{"886"}},
+	{Code: "YT", Name: "Mayotte", Currencies: []string{"978"}},
+	{Code: "ZA", Name: "South Africa", Currencies: []string{"710"}},
+	{Code: "ZM", Name: "Zambia", Currencies: []string{"967"}},
+	{Code: "ZW", Name: "Zimbabwe", Currencies: []string{"924", "840"}},
+}
+
+var countryIndex = func() map[string]Country {
+	m := make(map[string]Country, len(countries))
+	for _, c := range countries {
+		m[c.Code] = c
+	}
+	return m
+}()
+
+// Countries returns the ISO 3166-1 table.
+func Countries() []Country {
+	c := make([]Country, len(countries))
+	copy(c, countries)
+	return c
+}
+
+// LookupCountry returns the country with the alpha-2 code.
+func LookupCountry(code string) (Country, bool) {
+	c, ok := countryIndex[strings.ToUpper(code)]
+	return c, ok
+}
+
+// UsesCurrency reports whether currency, an ISO 4217 numeric code, is in
+// usual use in c.
+func (c Country) UsesCurrency(currency string) bool {
+	for _, v := range c.Currencies {
+		if v == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// Country returns the country of the Country Code.
+func (c *EMVQR) Country() (Country, bool) {
+	return LookupCountry(c.CountryCode.Value)
+}
+
+// Currency returns the currency of the Transaction Currency.
+func (c *EMVQR) Currency() (Currency, bool) {
+	if !FormatNumeric.Match(c.TransactionCurrency.Value) {
+		return Currency{}, false
+	}
+	return LookupCurrency(c.TransactionCurrency.Value)
+}
+
+// globalCurrencies are accepted outside their countries often enough that a
+// pair with them is only suspicious: US Dollar and Euro.
+var globalCurrencies = map[string]bool{
+	"840": true,
+	"978": true,
+}
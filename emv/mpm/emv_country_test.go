@@ -0,0 +1,100 @@
+package mpm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupCountry(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   Country
+		wantOK bool
+	}{
+		{code: "TH", want: Country{Code: "TH", Name: "Thailand", Currencies: []string{"764"}}, wantOK: true},
+		{code: "th", want: Country{Code: "TH", Name: "Thailand", Currencies: []string{"764"}}, wantOK: true},
+		{code: "ZZ", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := LookupCountry(tt.code)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+				t.Errorf("LookupCountry() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEMVQR_Country(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Country(); !ok || got.Name != "China" {
+		t.Errorf("EMVQR.Country() = %v, %v, want China", got, ok)
+	}
+	if got, ok := c.Currency(); !ok || got.Alpha != "CNY" {
+		t.Errorf("EMVQR.Currency() = %v, %v, want CNY", got, ok)
+	}
+}
+
+func TestEMVQR_ValidateAllWithOptions_CountryCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		currency string
+		opts     ValidationOptions
+		want     []Violation
+	}{
+		{
+			name:     "usual currency",
+			country:  "TH",
+			currency: "764",
+			opts:     ValidationOptions{CountryCurrency: true},
+		},
+		{
+			name:     "not checked by default",
+			country:  "TH",
+			currency: "392",
+		},
+		{
+			name:     "suspicious",
+			country:  "TH",
+			currency: "840",
+			opts:     ValidationOptions{CountryCurrency: true},
+			want: []Violation{
+				{Path: "53", Field: "TransactionCurrency", Rule: RuleCountryCurrency, Severity: SeverityWarning, Value: "840", Message: "TransactionCurrency USD is not in usual use in Thailand, TransactionCurrency: 840"},
+			},
+		},
+		{
+			name:     "impossible",
+			country:  "TH",
+			currency: "392",
+			opts:     ValidationOptions{CountryCurrency: true},
+			want: []Violation{
+				{Path: "53", Field: "TransactionCurrency", Rule: RuleCountryCurrency, Severity: SeverityError, Value: "392", Message: "TransactionCurrency JPY is not in usual use in Thailand, TransactionCurrency: 392"},
+			},
+		},
+		{
+			name:     "unknown country",
+			country:  "ZZ",
+			currency: "764",
+			opts:     ValidationOptions{CountryCurrency: true},
+			want: []Violation{
+				{Path: "58", Field: "CountryCode", Rule: RuleValue, Severity: SeverityError, Value: "ZZ", Message: "CountryCode should be an ISO 3166-1 alpha-2 code, CountryCode: ZZ"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			c.SetCountryCode(tt.country)
+			c.SetTransactionCurrency(tt.currency)
+			c.SetTransactionAmount("23")
+			got := c.ValidateAllWithOptions(tt.opts)
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAllWithOptions() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mpm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dongri/emv-qrcode/crc16"
+)
+
+// CRC errors ...
+var (
+	ErrCRCMissing = &ErrMandatoryMissing{Tag: IDCRC.String()}
+	ErrCRCNotLast = fmt.Errorf("CRC should be the last data object: %w", ErrInvalidFormat)
+)
+
+// CRCError is returned when the CRC in tag 63 does not match the checksum
+// calculated over the payload.
+type CRCError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *CRCError) Error() string {
+	return "CRC mismatch. expected: " + e.Expected + ", actual: " + e.Actual
+}
+
+// Is reports whether target is ErrCRCMismatch.
+func (e *CRCError) Is(target error) bool {
+	return target == ErrCRCMismatch
+}
+
+// checksum returns the CRC-16/CCITT-FALSE of data as 4 upper case hex digits.
+func checksum(data string) string {
+	table := crc16.MakeTable(crc16.CRC16_CCITT_FALSE)
+	crcValue := crc16.Checksum([]byte(data), table)
+	crcValueString := strconv.FormatUint(uint64(crcValue), 16)
+	s := "0000" + strings.ToUpper(crcValueString)
+	return s[len(s)-4:]
+}
+
+// verifyCRC checks the CRC value of the data object starting at offset
+// against everything in source up to and including "6304".
+func verifyCRC(source []rune, offset int64, value string) error {
+	end := offset + IDWordCount + ValueLengthWordCount
+	expected := checksum(string(source[:end]))
+	if value != expected {
+		return &CRCError{
+			Expected: expected,
+			Actual:   value,
+		}
+	}
+	return nil
+}
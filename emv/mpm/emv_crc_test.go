@@ -0,0 +1,47 @@
+package mpm
+
+import (
+	"testing"
+)
+
+func Test_checksum(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "check value",
+			data: "123456789",
+			want: "29B1",
+		},
+		{
+			name: "crc tag only",
+			data: "6304",
+			want: "6007",
+		},
+		{
+			name: "multibyte",
+			data: "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304",
+			want: "A13A",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checksum(tt.data); got != tt.want {
+				t.Errorf("checksum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCRCError_Error(t *testing.T) {
+	e := &CRCError{
+		Expected: "A13A",
+		Actual:   "FFFF",
+	}
+	want := "CRC mismatch. expected: A13A, actual: FFFF"
+	if got := e.Error(); got != want {
+		t.Errorf("CRCError.Error() = %v, want %v", got, want)
+	}
+}
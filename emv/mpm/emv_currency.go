@@ -0,0 +1,329 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency.
+type Currency struct {
+	Code       string // numeric code, the value of ID "53", e.g. "392"
+	Alpha      string // alphabetic code, e.g. "JPY"
+	Name       string
+	MinorUnits int // number of digits after the decimal mark
+}
+
+// ErrUnknownCurrency is returned for a currency that is not in the ISO 4217
+// table.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// currencies is the ISO 4217 list of active currencies with minor units.
+var currencies = []Currency{
+	{Code: "784", Alpha: "AED", Name: "UAE Dirham", MinorUnits: 2},
+	{Code: "971", Alpha: "AFN", Name: "Afghani", MinorUnits: 2},
+	{Code: "008", Alpha: "ALL", Name: "Lek", MinorUnits: 2},
+	{Code: "051", Alpha: "AMD", Name: "Armenian Dram", MinorUnits: 2},
+	{Code: "973", Alpha: "AOA", Name: "Kwanza", MinorUnits: 2},
+	{Code: "032", Alpha: "ARS", Name: "Argentine Peso", MinorUnits: 2},
+	{Code: "036", Alpha: "AUD", Name: "Australian Dollar", MinorUnits: 2},
+	{Code: "533", Alpha: "AWG", Name: "Aruban Florin", MinorUnits: 2},
+	{Code: "944", Alpha: "AZN", Name: "Azerbaijan Manat", MinorUnits: 2},
+	{Code: "977", Alpha: "BAM", Name: "Convertible Mark", MinorUnits: 2},
+	{Code: "052", Alpha: "BBD", Name: "Barbados Dollar", MinorUnits: 2},
+	{Code: "050", Alpha: "BDT", Name: "Taka", MinorUnits: 2},
+	{Code: "975", Alpha: "BGN", Name: "Bulgarian Lev", MinorUnits: 2},
+	{Code: "048", Alpha: "BHD", Name: "Bahraini Dinar", MinorUnits: 3},
+	{Code: "108", Alpha: "BIF", Name: "Burundi Franc", MinorUnits: 0},
+	{Code: "060", Alpha: "BMD", Name: "Bermudian Dollar", MinorUnits: 2},
+	{Code: "096", Alpha: "BND", Name: "Brunei Dollar", MinorUnits: 2},
+	{Code: "068", Alpha: "BOB", Name: "Boliviano", MinorUnits: 2},
+	{Code: "984", Alpha: "BOV", Name: "Mvdol", MinorUnits: 2},
+	{Code: "986", Alpha: "BRL", Name: "Brazilian Real", MinorUnits: 2},
+	{Code: "044", Alpha: "BSD", Name: "Bahamian Dollar", MinorUnits: 2},
+	{Code: "064", Alpha: "BTN", Name: "Ngultrum", MinorUnits: 2},
+	{Code: "072", Alpha: "BWP", Name: "Pula", MinorUnits: 2},
+	{Code: "933", Alpha: "BYN", Name: "Belarusian Ruble", MinorUnits: 2},
+	{Code: "084", Alpha: "BZD", Name: "Belize Dollar", MinorUnits: 2},
+	{Code: "124", Alpha: "CAD", Name: "Canadian Dollar", MinorUnits: 2},
+	{Code: "976", Alpha: "CDF", Name: "Congolese Franc", MinorUnits: 2},
+	{Code: "947", Alpha: "CHE", Name: "WIR Euro", MinorUnits: 2},
+	{Code: "756", Alpha: "CHF", Name: "Swiss Franc", MinorUnits: 2},
+	{Code: "948", Alpha: "CHW", Name: "WIR Franc", MinorUnits: 2},
+	{Code: "990", Alpha: "CLF", Name: "Unidad de Fomento", MinorUnits: 4},
+	{Code: "152", Alpha: "CLP", Name: "Chilean Peso", MinorUnits: 0},
+	{Code: "156", Alpha: "CNY", Name: "Yuan Renminbi", MinorUnits: 2},
+	{Code: "170", Alpha: "COP", Name: "Colombian Peso", MinorUnits: 2},
+	{Code: "970", Alpha: "COU", Name: "Unidad de Valor Real", MinorUnits: 2},
+	{Code: "188", Alpha: "CRC", Name: "Costa Rican Colon", MinorUnits: 2},
+	{Code: "192", Alpha: "CUP", Name: "Cuban Peso", MinorUnits: 2},
+	{Code: "132", Alpha: "CVE", Name: "Cabo Verde Escudo", MinorUnits: 2},
+	{Code: "203", Alpha: "CZK", Name: "Czech Koruna", MinorUnits: 2},
+	{Code: "262", Alpha: "DJF", Name: "Djibouti Franc", MinorUnits: 0},
+	{Code: "208", Alpha: "DKK", Name: "Danish Krone", MinorUnits: 2},
+	{Code: "214", Alpha: "DOP", Name: "Dominican Peso", MinorUnits: 2},
+	{Code: "012", Alpha: "DZD", Name: "Algerian Dinar", MinorUnits: 2},
+	{Code: "818", Alpha: "EGP", Name: "Egyptian Pound", MinorUnits: 2},
+	{Code: "232", Alpha: "ERN", Name: "Nakfa", MinorUnits: 2},
+	{Code: "230", Alpha: "ETB", Name: "Ethiopian Birr", MinorUnits: 2},
+	{Code: "978", Alpha: "EUR", Name: "Euro", MinorUnits: 2},
+	{Code: "242", Alpha: "FJD", Name: "Fiji Dollar", MinorUnits: 2},
+	{Code: "238", Alpha: "FKP", Name: "Falkland Islands Pound", MinorUnits: 2},
+	{Code: "826", Alpha: "GBP", Name: "Pound Sterling", MinorUnits: 2},
+	{Code: "981", Alpha: "GEL", Name: "Lari", MinorUnits: 2},
+	{Code: "936", Alpha: "GHS", Name: "Ghana Cedi", MinorUnits: 2},
+	{Code: "292", Alpha: "GIP", Name: "Gibraltar Pound", MinorUnits: 2},
+	{Code: "270", Alpha: "GMD", Name: "Dalasi", MinorUnits: 2},
+	{Code: "324", Alpha: "GNF", Name: "Guinean Franc", MinorUnits: 0},
+	{Code: "320", Alpha: "GTQ", Name: "Quetzal", MinorUnits: 2},
+	{Code: "328", Alpha: "GYD", Name: "Guyana Dollar", MinorUnits: 2},
+	{Code: "344", Alpha: "HKD", Name: "Hong Kong Dollar", MinorUnits: 2},
+	{Code: "340", Alpha: "HNL", Name: "Lempira", MinorUnits: 2},
+	{Code: "332", Alpha: "HTG", Name: "Gourde", MinorUnits: 2},
+	{Code: "348", Alpha: "HUF", Name: "Forint", MinorUnits: 2},
+	{Code: "360", Alpha: "IDR", Name: "Rupiah", MinorUnits: 2},
+	{Code: "376", Alpha: "ILS", Name: "New Israeli Sheqel", MinorUnits: 2},
+	{Code: "356", Alpha: "INR", Name: "Indian Rupee", MinorUnits: 2},
+	{Code: "368", Alpha: "IQD", Name: "Iraqi Dinar", MinorUnits: 3},
+	{Code: "364", Alpha: "IRR", Name: "Iranian Rial", MinorUnits: 2},
+	{Code: "352", Alpha: "ISK", Name: "Iceland Krona", MinorUnits: 0},
+	{Code: "388", Alpha: "JMD", Name: "Jamaican Dollar", MinorUnits: 2},
+	{Code: "400", Alpha: "JOD", Name: "Jordanian Dinar", MinorUnits: 3},
+	{Code: "392", Alpha: "JPY", Name: "Yen", MinorUnits: 0},
+	{Code: "404", Alpha: "KES", Name: "Kenyan Shilling", MinorUnits: 2},
+	{Code: "417", Alpha: "KGS", Name: "Som", MinorUnits: 2},
+	{Code: "116", Alpha: "KHR", Name: "Riel", MinorUnits: 2},
+	{Code: "174", Alpha: "KMF", Name: "Comorian Franc", MinorUnits: 0},
+	{Code: "408", Alpha: "KPW", Name: "North Korean Won", MinorUnits: 2},
+	{Code: "410", Alpha: "KRW", Name: "Won", MinorUnits: 0},
+	{Code: "414", Alpha: "KWD", Name: "Kuwaiti Dinar", MinorUnits: 3},
+	{Code: "136", Alpha: "KYD", Name: "Cayman Islands Dollar", MinorUnits: 2},
+	{Code: "398", Alpha: "KZT", Name: "Tenge", MinorUnits: 2},
+	{Code: "418", Alpha: "LAK", Name: "Lao Kip", MinorUnits: 2},
+	{Code: "422", Alpha: "LBP", Name: "Lebanese Pound", MinorUnits: 2},
+	{Code: "144", Alpha: "LKR", Name: "Sri Lanka Rupee", MinorUnits: 2},
+	{Code: "430", Alpha: "LRD", Name: "Liberian Dollar", MinorUnits: 2},
+	{Code: "426", Alpha: "LSL", Name: "Loti", MinorUnits: 2},
+	{Code: "434", Alpha: "LYD", Name: "Libyan Dinar", MinorUnits: 3},
+	{Code: "504", Alpha: "MAD", Name: "Moroccan Dirham", MinorUnits: 2},
+	{Code: "498", Alpha: "MDL", Name: "Moldovan Leu", MinorUnits: 2},
+	{Code: "969", Alpha: "MGA", Name: "Malagasy Ariary", MinorUnits: 2},
+	{Code: "807", Alpha: "MKD", Name: "Denar", MinorUnits: 2},
+	{Code: "104", Alpha: "MMK", Name: "Kyat", MinorUnits: 2},
+	{Code: "496", Alpha: "MNT", Name: "Tugrik", MinorUnits: 2},
+	{Code: "446", Alpha: "MOP", Name: "Pataca", MinorUnits: 2},
+	{Code: "929", Alpha: "MRU", Name: "Ouguiya", MinorUnits: 2},
+	{Code: "480", Alpha: "MUR", Name: "Mauritius Rupee", MinorUnits: 2},
+	{Code: "462", Alpha: "MVR", Name: "Rufiyaa", MinorUnits: 2},
+	{Code: "454", Alpha: "MWK", Name: "Malawi Kwacha", MinorUnits: 2},
+	{Code: "484", Alpha: "MXN", Name: "Mexican Peso", MinorUnits: 2},
+	{Code: "979", Alpha: "MXV", Name: "Mexican Unidad de Inversion (UDI)", MinorUnits: 2},
+	{Code: "458", Alpha: "MYR", Name: "Malaysian Ringgit", MinorUnits: 2},
+	{Code: "943", Alpha: "MZN", Name: "Mozambique Metical", MinorUnits: 2},
+	{Code: "516", Alpha: "NAD", Name: "Namibia Dollar", MinorUnits: 2},
+	{Code: "566", Alpha: "NGN", Name: "Naira", MinorUnits: 2},
+	{Code: "558", Alpha: "NIO", Name: "Cordoba Oro", MinorUnits: 2},
+	{Code: "578", Alpha: "NOK", Name: "Norwegian Krone", MinorUnits: 2},
+	{Code: "524", Alpha: "NPR", Name: "Nepalese Rupee", MinorUnits: 2},
+	{Code: "554", Alpha: "NZD", Name: "New Zealand Dollar", MinorUnits: 2},
+	{Code: "512", Alpha: "OMR", Name: "Rial Omani", MinorUnits: 3},
+	{Code: "590", Alpha: "PAB", Name: "Balboa", MinorUnits: 2},
+	{Code: "604", Alpha: "PEN", Name: "Sol", MinorUnits: 2},
+	{Code: "598", Alpha: "PGK", Name: "Kina", MinorUnits: 2},
+	{Code: "608", Alpha: "PHP", Name: "Philippine Peso", MinorUnits: 2},
+	{Code: "586", Alpha: "PKR", Name: "Pakistan Rupee", MinorUnits: 2},
+	{Code: "985", Alpha: "PLN", Name: "Zloty", MinorUnits: 2},
+	{Code: "600", Alpha: "PYG", Name: "Guarani", MinorUnits: 0},
+	{Code: "634", Alpha: "QAR", Name: "Qatari Rial", MinorUnits: 2},
+	{Code: "946", Alpha: "RON", Name: "Romanian Leu", MinorUnits: 2},
+	{Code: "941", Alpha: "RSD", Name: "Serbian Dinar", MinorUnits: 2},
+	{Code: "643", Alpha: "RUB", Name: "Russian Ruble", MinorUnits: 2},
+	{Code: "646", Alpha: "RWF", Name: "Rwanda Franc", MinorUnits: 0},
+	{Code: "682", Alpha: "SAR", Name: "Saudi Riyal", MinorUnits: 2},
+	{Code: "090", Alpha: "SBD", Name: "Solomon Islands Dollar", MinorUnits: 2},
+	{Code: "690", Alpha: "SCR", Name: "Seychelles Rupee", MinorUnits: 2},
+	{Code: "938", Alpha: "SDG", Name: "Sudanese Pound", MinorUnits: 2},
+	{Code: "752", Alpha: "SEK", Name: "Swedish Krona", MinorUnits: 2},
+	{Code: "702", Alpha: "SGD", Name: "Singapore Dollar", MinorUnits: 2},
+	{Code: "654", Alpha: "SHP", Name: "Saint Helena Pound", MinorUnits: 2},
+	{Code: "925", Alpha: "SLE", Name: "Leone", MinorUnits: 2},
+	{Code: "706", Alpha: "SOS", Name: "Somali Shilling", MinorUnits: 2},
+	{Code: "968", Alpha: "SRD", Name: "Surinam Dollar", MinorUnits: 2},
+	{Code: "728", Alpha: "SSP", Name: "South Sudanese Pound", MinorUnits: 2},
+	{Code: "930", Alpha: "STN", Name: "Dobra", MinorUnits: 2},
+	{Code: "222", Alpha: "SVC", Name: "El Salvador Colon", MinorUnits: 2},
+	{Code: "760", Alpha: "SYP", Name: "Syrian Pound", MinorUnits: 2},
+	{Code: "748", Alpha: "SZL", Name: "Lilangeni", MinorUnits: 2},
+	{Code: "764", Alpha: "THB", Name: "Baht", MinorUnits: 2},
+	{Code: "972", Alpha: "TJS", Name: "Somoni", MinorUnits: 2},
+	{Code: "934", Alpha: "TMT", Name: "Turkmenistan New Manat", MinorUnits: 2},
+	{Code: "788", Alpha: "TND", Name: "Tunisian Dinar", MinorUnits: 3},
+	{Code: "776", Alpha: "TOP", Name: "Pa'anga", MinorUnits: 2},
+	{Code: "949", Alpha: "TRY", Name: "Turkish Lira", MinorUnits: 2},
+	{Code: "780", Alpha: "TTD", Name: "Trinidad and Tobago Dollar", MinorUnits: 2},
+	{Code: "901", Alpha: "TWD", Name: "New Taiwan Dollar", MinorUnits: 2},
+	{Code: "834", Alpha: "TZS", Name: "Tanzanian Shilling", MinorUnits: 2},
+	{Code: "980", Alpha: "UAH", Name: "Hryvnia", MinorUnits: 2},
+	{Code: "800", Alpha: "UGX", Name: "Uganda Shilling", MinorUnits: 0},
+	{Code: "840", Alpha: "USD", Name: "US Dollar", MinorUnits: 2},
+	{Code: "997", Alpha: "USN", Name: "US Dollar (Next day)", MinorUnits: 2},
+	{Code: "940", Alpha: "UYI", Name: "Uruguay Peso en Unidades Indexadas (UI)", MinorUnits: 0},
+	{Code: "858", Alpha: "UYU", Name: "Peso Uruguayo", MinorUnits: 2},
+	{Code: "927", Alpha: "UYW", Name: "Unidad Previsional", MinorUnits: 4},
+	{Code: "860", Alpha: "UZS", Name: "Uzbekistan Sum", MinorUnits: 2},
+	{Code: "926", Alpha: "VED", Name: "Bolivar Soberano", MinorUnits: 2},
+	{Code: "928", Alpha: "VES", Name: "Bolivar Soberano", MinorUnits: 2},
+	{Code: "704", Alpha: "VND", Name: "Dong", MinorUnits: 0},
+	{Code: "548", Alpha: "VUV", Name: "Vatu", MinorUnits: 0},
+	{Code: "882", Alpha: "WST", Name: "Tala", MinorUnits: 2},
+	{Code: "950", Alpha: "XAF", Name: "CFA Franc BEAC", MinorUnits: 0},
+	{Code: "951", Alpha: "XCD", Name: "East Caribbean Dollar", MinorUnits: 2},
+	{Code: "532", Alpha: "XCG", Name: "Caribbean Guilder", MinorUnits: 2},
+	{Code: "952", Alpha: "XOF", Name: "CFA Franc BCEAO", MinorUnits: 0},
+	{Code: "953", Alpha: "XPF", Name: "CFP Franc", MinorUnits: 0},
+	{Code: "886", Alpha: "YER", Name: "Yemeni Rial", MinorUnits: 2},
+	{Code: "710", Alpha: "ZAR", Name: "Rand", MinorUnits: 2},
+	{Code: "967", Alpha: "ZMW", Name: "Zambian Kwacha", MinorUnits: 2},
+	{Code: "924", Alpha: "ZWG", Name: "Zimbabwe Gold", MinorUnits: 2},
+}
+
+var currencyIndex = func() map[string]Currency {
+	m := make(map[string]Currency, len(currencies)*2)
+	for _, c := range currencies {
+		m[c.Code] = c
+		m[c.Alpha] = c
+	}
+	return m
+}()
+
+// Currencies returns the ISO 4217 table.
+func Currencies() []Currency {
+	c := make([]Currency, len(currencies))
+	copy(c, currencies)
+	return c
+}
+
+// LookupCurrency returns the currency with the numeric or alphabetic code.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencyIndex[strings.ToUpper(code)]
+	return c, ok
+}
+
+// RoundingMode is the rounding applied to digits beyond the minor units of
+// a currency.
+type RoundingMode int
+
+// const ...
+const (
+	// RoundDefault is RoundHalfUp.
+	RoundDefault RoundingMode = iota
+	// RoundExact returns an error instead of rounding.
+	RoundExact
+	// RoundDown rounds towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest, halves away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest, halves to the even neighbour.
+	RoundHalfEven
+)
+
+// resolved returns the mode m resolves to.
+func (m RoundingMode) resolved() RoundingMode {
+	if m == RoundDefault {
+		return RoundHalfUp
+	}
+	return m
+}
+
+// FormatAmount returns minor, an amount in minor units of currency, as the
+// value of a Transaction Amount (ID "54"), e.g. 12345 USD is "123.45".
+func FormatAmount(minor int64, currency string) (string, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return "", fmt.Errorf("currency: %s: %w", currency, ErrUnknownCurrency)
+	}
+	if minor < 0 {
+		return "", fmt.Errorf("amount should not be negative, amount: %d: %w", minor, ErrInvalidFormat)
+	}
+	s := strconv.FormatInt(minor, 10)
+	if c.MinorUnits == 0 {
+		return s, nil
+	}
+	if n := c.MinorUnits + 1 - len(s); n > 0 {
+		s = strings.Repeat("0", n) + s
+	}
+	return s[:len(s)-c.MinorUnits] + "." + s[len(s)-c.MinorUnits:], nil
+}
+
+// ParseAmount returns amount, the value of a Transaction Amount (ID "54"),
+// in minor units of currency. Digits beyond the minor units are rounded
+// with mode.
+func ParseAmount(amount string, currency string, mode RoundingMode) (int64, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return 0, fmt.Errorf("currency: %s: %w", currency, ErrUnknownCurrency)
+	}
+	if !amountPattern.MatchString(amount) {
+		return 0, fmt.Errorf("amount should be digits with an optional \".\" decimal mark, amount: %s: %w", amount, ErrInvalidFormat)
+	}
+	whole, fraction := amount, ""
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		whole, fraction = amount[:i], amount[i+1:]
+	}
+	rest := ""
+	if len(fraction) > c.MinorUnits {
+		fraction, rest = fraction[:c.MinorUnits], fraction[c.MinorUnits:]
+	}
+	fraction += strings.Repeat("0", c.MinorUnits-len(fraction))
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount out of range, amount: %s: %w", amount, ErrInvalidFormat)
+	}
+	if strings.Trim(rest, "0") == "" {
+		return minor, nil
+	}
+	switch mode.resolved() {
+	case RoundDown:
+	case RoundUp:
+		minor++
+	case RoundHalfUp:
+		if rest[0] >= '5' {
+			minor++
+		}
+	case RoundHalfEven:
+		if rest[0] > '5' || (rest[0] == '5' && (strings.Trim(rest[1:], "0") != "" || minor%2 == 1)) {
+			minor++
+		}
+	default:
+		return 0, fmt.Errorf("amount has more than %d decimals for %s, amount: %s: %w", c.MinorUnits, c.Alpha, amount, ErrInvalidFormat)
+	}
+	return minor, nil
+}
+
+// SetTransactionAmountMinor sets the Transaction Amount from minor, an amount
+// in minor units of the Transaction Currency.
+func (c *EMVQR) SetTransactionAmountMinor(minor int64) error {
+	v, err := FormatAmount(minor, c.TransactionCurrency.Value)
+	if err != nil {
+		return err
+	}
+	c.SetTransactionAmount(v)
+	return nil
+}
+
+// TransactionAmountMinor returns the Transaction Amount in minor units of
+// the Transaction Currency.
+func (c *EMVQR) TransactionAmountMinor(mode RoundingMode) (int64, error) {
+	return ParseAmount(c.TransactionAmount.Value, c.TransactionCurrency.Value, mode)
+}
+
+// decimals returns the number of digits after the decimal mark of amount.
+func decimals(amount string) int {
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		return len(amount) - i - 1
+	}
+	return 0
+}
@@ -0,0 +1,170 @@
+package mpm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   Currency
+		wantOK bool
+	}{
+		{code: "392", want: Currency{Code: "392", Alpha: "JPY", Name: "Yen", MinorUnits: 0}, wantOK: true},
+		{code: "jpy", want: Currency{Code: "392", Alpha: "JPY", Name: "Yen", MinorUnits: 0}, wantOK: true},
+		{code: "048", want: Currency{Code: "048", Alpha: "BHD", Name: "Bahraini Dinar", MinorUnits: 3}, wantOK: true},
+		{code: "354", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := LookupCurrency(tt.code)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupCurrency() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		minor    int64
+		currency string
+		want     string
+		wantErr  error
+	}{
+		{name: "yen", minor: 999, currency: "392", want: "999"},
+		{name: "dollar", minor: 12345, currency: "840", want: "123.45"},
+		{name: "cent", minor: 5, currency: "USD", want: "0.05"},
+		{name: "zero", minor: 0, currency: "840", want: "0.00"},
+		{name: "dinar", minor: 1500, currency: "048", want: "1.500"},
+		{name: "negative", minor: -1, currency: "840", wantErr: ErrInvalidFormat},
+		{name: "unknown currency", minor: 1, currency: "354", wantErr: ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatAmount(tt.minor, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FormatAmount() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		mode     RoundingMode
+		want     int64
+		wantErr  error
+	}{
+		{name: "exact", amount: "123.45", currency: "840", mode: RoundExact, want: 12345},
+		{name: "fewer decimals", amount: "123.4", currency: "840", mode: RoundExact, want: 12340},
+		{name: "no decimals", amount: "123", currency: "840", mode: RoundExact, want: 12300},
+		{name: "trailing zeros", amount: "999.000", currency: "392", mode: RoundExact, want: 999},
+		{name: "exact needs rounding", amount: "999.123", currency: "392", mode: RoundExact, wantErr: ErrInvalidFormat},
+		{name: "down", amount: "999.9", currency: "392", mode: RoundDown, want: 999},
+		{name: "up", amount: "999.1", currency: "392", mode: RoundUp, want: 1000},
+		{name: "half up below", amount: "1.004", currency: "840", mode: RoundHalfUp, want: 100},
+		{name: "half up half", amount: "1.005", currency: "840", mode: RoundHalfUp, want: 101},
+		{name: "default", amount: "1.005", currency: "840", want: 101},
+		{name: "half even half to even", amount: "1.005", currency: "840", mode: RoundHalfEven, want: 100},
+		{name: "half even half to odd", amount: "1.015", currency: "840", mode: RoundHalfEven, want: 102},
+		{name: "half even above half", amount: "1.0051", currency: "840", mode: RoundHalfEven, want: 101},
+		{name: "invalid", amount: "1,5", currency: "840", mode: RoundExact, wantErr: ErrInvalidFormat},
+		{name: "overflow", amount: "99999999999999999999", currency: "840", mode: RoundExact, wantErr: ErrInvalidFormat},
+		{name: "unknown currency", amount: "1", currency: "354", mode: RoundExact, wantErr: ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.amount, tt.currency, tt.mode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAmount() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEMVQR_TransactionAmountMinor(t *testing.T) {
+	c := validEMVQR()
+	if err := c.SetTransactionAmountMinor(2372); err != nil {
+		t.Fatal(err)
+	}
+	if c.TransactionAmount != (TLV{IDTransactionAmount, "05", "23.72"}) {
+		t.Errorf("EMVQR.TransactionAmount = %v", c.TransactionAmount)
+	}
+	got, err := c.TransactionAmountMinor(RoundExact)
+	if err != nil || got != 2372 {
+		t.Errorf("EMVQR.TransactionAmountMinor() = %v, %v, want 2372", got, err)
+	}
+}
+
+func TestEMVQR_ValidateAll_Currency(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		want   []Violation
+	}{
+		{
+			name: "fewer decimals",
+			modify: func(c *EMVQR) {
+				c.SetTransactionAmount("23.7")
+			},
+		},
+		{
+			name: "too many decimals for yen",
+			modify: func(c *EMVQR) {
+				c.SetTransactionCurrency("392")
+				c.SetTransactionAmount("999.123")
+				c.SetTipOrConvenienceIndicator("02")
+				c.SetValueOfConvenienceFeeFixed("10")
+			},
+			want: []Violation{
+				{Path: "54", Field: "TransactionAmount", Rule: RuleValue, Severity: SeverityError, Value: "999.123", Message: "TransactionAmount should have at most 0 decimals for JPY, TransactionAmount: 999.123"},
+			},
+		},
+		{
+			name: "too many decimals for the fee",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("02")
+				c.SetValueOfConvenienceFeeFixed("1.005")
+			},
+			want: []Violation{
+				{Path: "56", Field: "ValueOfConvenienceFeeFixed", Rule: RuleValue, Severity: SeverityError, Value: "1.005", Message: "ValueOfConvenienceFeeFixed should have at most 2 decimals for CNY, ValueOfConvenienceFeeFixed: 1.005"},
+			},
+		},
+		{
+			name: "unknown currency",
+			modify: func(c *EMVQR) {
+				c.SetTransactionCurrency("354")
+			},
+			want: []Violation{
+				{Path: "53", Field: "TransactionCurrency", Rule: RuleValue, Severity: SeverityWarning, Value: "354", Message: "TransactionCurrency should be an ISO 4217 numeric code, TransactionCurrency: 354"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got := c.ValidateAll()
+			if len(got.Violations) != len(tt.want) {
+				t.Fatalf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+			for i := range tt.want {
+				if got.Violations[i] != tt.want[i] {
+					t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
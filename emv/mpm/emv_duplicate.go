@@ -0,0 +1,130 @@
+package mpm
+
+import "fmt"
+
+// DuplicatePolicy decides what a decode does with an ID that appears more
+// than once in the same template.
+type DuplicatePolicy int
+
+// const ...
+const (
+	// DuplicateDefault is DuplicateReject, in every mode.
+	DuplicateDefault DuplicatePolicy = iota
+	// DuplicateReject fails the decode with an error wrapping
+	// ErrDuplicateTag.
+	DuplicateReject
+	// DuplicateKeepFirst decodes the first occurrence and ignores the others.
+	DuplicateKeepFirst
+	// DuplicateKeepLast decodes the last occurrence and ignores the others.
+	DuplicateKeepLast
+	// DuplicateCollect is DuplicateKeepFirst. Every policy but
+	// DuplicateReject records the ignored occurrences, see
+	// EMVQR.Duplicates.
+	DuplicateCollect
+)
+
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicateReject:
+		return "reject"
+	case DuplicateKeepFirst:
+		return "keep-first"
+	case DuplicateKeepLast:
+		return "keep-last"
+	case DuplicateCollect:
+		return "collect"
+	}
+	return "default"
+}
+
+// Duplicate is an occurrence of a repeated ID that a decode ignored.
+type Duplicate struct {
+	Path  string `json:"path"` // tag path, e.g. "62.05"
+	Value string `json:"value"`
+}
+
+// Duplicates returns the occurrences of repeated IDs that the decode of c
+// ignored, in payload order. ValidateAll reports each of them as a warning.
+func (c *EMVQR) Duplicates() []Duplicate {
+	return c.duplicates
+}
+
+// duplicatePolicy returns the policy opts resolves to.
+func (opts DecodeOptions) duplicatePolicy() DuplicatePolicy {
+	if opts.Duplicates == DuplicateDefault {
+		return DuplicateReject
+	}
+	return opts.Duplicates
+}
+
+// occurrences applies a DuplicatePolicy to the data objects of a template.
+type occurrences struct {
+	policy DuplicatePolicy
+	seen   map[ID]int
+	total  map[ID]int // occurrences in the template, for DuplicateKeepLast
+}
+
+func newOccurrences(value string, opts DecodeOptions) *occurrences {
+	o := &occurrences{
+		policy: opts.duplicatePolicy(),
+		seen:   make(map[ID]int),
+	}
+	if o.policy == DuplicateKeepLast {
+		o.total = make(map[ID]int)
+		p := NewParser(value)
+		for p.Next() {
+			o.total[p.ID()]++
+		}
+	}
+	return o
+}
+
+// check counts the data object id that p stopped at. It returns true if the
+// data object should be decoded into its field, and an error if the policy
+// rejects it.
+func (o *occurrences) check(p *Parser, fn string, id ID) (bool, error) {
+	o.seen[id]++
+	n := o.seen[id]
+	switch {
+	case o.policy == DuplicateKeepLast:
+		return n == o.total[id], nil
+	case n > 1 && o.policy == DuplicateReject:
+		return false, duplicateError(fn, id).at(p.current, id.String())
+	}
+	return n == 1, nil
+}
+
+func duplicateError(fn string, id ID) *ParserError {
+	return &ParserError{
+		Func: fn,
+		Err:  fmt.Errorf("duplicate id. id: %s: %w", id.String(), ErrDuplicateTag),
+	}
+}
+
+// findDuplicates returns the occurrences of repeated IDs of nodes, the data
+// objects of the template at parent, that policy ignores, and those in the
+// templates it keeps.
+func findDuplicates(parent string, nodes []*Node, policy DuplicatePolicy) []Duplicate {
+	kept := make(map[ID]int)
+	for i, n := range nodes {
+		if _, ok := kept[n.ID]; !ok || policy == DuplicateKeepLast {
+			kept[n.ID] = i
+		}
+	}
+	var found []Duplicate
+	for i, n := range nodes {
+		path := tagPath(parent, n.ID)
+		if kept[n.ID] != i {
+			found = append(found, Duplicate{Path: path, Value: n.Data()})
+			continue
+		}
+		found = append(found, findDuplicates(path, n.Children, policy)...)
+	}
+	return found
+}
+
+func (r *ValidationReport) duplicates(c *EMVQR) {
+	for _, d := range c.duplicates {
+		r.warn(d.Path, "DataObject", RuleDuplicate, d.Value, "DataObject %s should not be repeated, ignored value: %s", d.Path, d.Value)
+	}
+}
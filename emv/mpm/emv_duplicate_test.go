@@ -0,0 +1,146 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeWithOptions_Duplicates(t *testing.T) {
+	body := "000201010212" + format("29", "0012D156000000000510A93FO3230Q") + "520441115303156" + "5802CN5914BEST TRANSPORT6007BEIJING"
+	type want struct {
+		value      string
+		duplicates []Duplicate
+	}
+	tests := []struct {
+		name    string
+		payload string
+		path    string
+		value   func(c *EMVQR) string
+		first   want
+		last    want
+	}{
+		{
+			name:    "primitive",
+			payload: withCRC(body + "540510.00" + "540520.00"),
+			value:   func(c *EMVQR) string { return c.TransactionAmount.Value },
+			first:   want{value: "10.00", duplicates: []Duplicate{{Path: "54", Value: "20.00"}}},
+			last:    want{value: "20.00", duplicates: []Duplicate{{Path: "54", Value: "10.00"}}},
+			path:    "54",
+		},
+		{
+			name:    "template",
+			payload: withCRC(body + format("26", "00041234") + format("26", "00045678"+"0102ab")),
+			value: func(c *EMVQR) string {
+				return c.MerchantAccountInformation["26"].Value.GloballyUniqueIdentifier.Value
+			},
+			first: want{value: "1234", duplicates: []Duplicate{{Path: "26", Value: "000456780102ab"}}},
+			last:  want{value: "5678", duplicates: []Duplicate{{Path: "26", Value: "00041234"}}},
+			path:  "26",
+		},
+		{
+			name:    "nested",
+			payload: withCRC(body + format("62", "0503123"+"0702T1"+"0503456")),
+			value:   func(c *EMVQR) string { return c.AdditionalDataFieldTemplate.ReferenceLabel.Value },
+			first:   want{value: "123", duplicates: []Duplicate{{Path: "62.05", Value: "456"}}},
+			last:    want{value: "456", duplicates: []Duplicate{{Path: "62.05", Value: "123"}}},
+			path:    "62.05",
+		},
+		{
+			name:    "nested in a payment system specific template",
+			payload: withCRC(body + format("62", format("50", "0004hoge"+"0102ab"+"0102cd"))),
+			value: func(c *EMVQR) string {
+				return c.AdditionalDataFieldTemplate.PaymentSystemSpecific[0].Value.PaymentSystemSpecific[0].Value
+			},
+			first: want{value: "ab", duplicates: []Duplicate{{Path: "62.50.01", Value: "cd"}}},
+			last:  want{value: "cd", duplicates: []Duplicate{{Path: "62.50.01", Value: "ab"}}},
+			path:  "62.50.01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, policy := range []DuplicatePolicy{DuplicateDefault, DuplicateReject} {
+				_, err := DecodeWithOptions(tt.payload, DecodeOptions{Duplicates: policy})
+				var perr *ParserError
+				if !errors.Is(err, ErrDuplicateTag) || !errors.As(err, &perr) || perr.Path != tt.path {
+					t.Errorf("DecodeWithOptions(%v) error = %v, want ErrDuplicateTag at %v", policy, err, tt.path)
+				}
+			}
+			for _, policy := range []DuplicatePolicy{DuplicateKeepFirst, DuplicateKeepLast, DuplicateCollect} {
+				w := tt.first
+				if policy == DuplicateKeepLast {
+					w = tt.last
+				}
+				for _, lossless := range []bool{false, true} {
+					c, err := DecodeWithOptions(tt.payload, DecodeOptions{Duplicates: policy, Lossless: lossless})
+					if err != nil {
+						t.Fatalf("DecodeWithOptions(%v) error = %v", policy, err)
+					}
+					if got := tt.value(c); got != w.value {
+						t.Errorf("DecodeWithOptions(%v) = %v, want %v", policy, got, w.value)
+					}
+					if got := c.Duplicates(); !reflect.DeepEqual(got, w.duplicates) {
+						t.Errorf("EMVQR.Duplicates() = %+v, want %+v", got, w.duplicates)
+					}
+					if lossless {
+						if s, err := c.GeneratePayload(); err != nil || s != tt.payload {
+							t.Errorf("GeneratePayload() = %v, %v, want %v", s, err, tt.payload)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeWithOptions_DuplicatesLossless(t *testing.T) {
+	body := "000201010212" + format("29", "0012D156000000000510A93FO3230Q") + "520441115303156" + "5802CN5914BEST TRANSPORT6007BEIJING"
+	payload := withCRC(body + "540410.0" + "5403999")
+	for _, policy := range []DuplicatePolicy{DuplicateDefault, DuplicateReject} {
+		if _, err := DecodeWithOptions(payload, DecodeOptions{Lossless: true, Duplicates: policy}); !errors.Is(err, ErrDuplicateTag) {
+			t.Errorf("DecodeWithOptions(%v) error = %v, want ErrDuplicateTag", policy, err)
+		}
+	}
+	c, err := DecodeWithOptions(payload, DecodeOptions{Lossless: true, Duplicates: DuplicateKeepFirst})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.TransactionAmount.Value != "10.0" {
+		t.Errorf("TransactionAmount = %v, want 10.0", c.TransactionAmount.Value)
+	}
+	want := []Violation{
+		{Path: "54", Field: "DataObject", Rule: RuleDuplicate, Severity: SeverityWarning, Value: "999", Message: "DataObject 54 should not be repeated, ignored value: 999"},
+	}
+	if got := c.ValidateAll().Violations; !reflect.DeepEqual(got, want) {
+		t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got, want)
+	}
+	nested := withCRC("000201" + format("62", "0503ABC"+"0503XYZ"))
+	_, err = ParseEMVQRWithOptions(nested, DecodeOptions{Lossless: true, Duplicates: DuplicateReject})
+	var perr *ParserError
+	if !errors.Is(err, ErrDuplicateTag) || !errors.As(err, &perr) || perr.Path != "62.05" {
+		t.Errorf("ParseEMVQRWithOptions() error = %v, want ErrDuplicateTag at 62.05", err)
+	}
+}
+
+func TestEMVQR_ValidateAll_Duplicates(t *testing.T) {
+	body := "000201010212" + format("29", "0012D156000000000510A93FO3230Q") + "520441115303156" + "5802CN5914BEST TRANSPORT6007BEIJING"
+	c, err := DecodeWithOptions(withCRC(body+format("62", "0503123"+"0503456")), DecodeOptions{Duplicates: DuplicateCollect})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Violation
+	for _, v := range c.ValidateAll().Violations {
+		if v.Rule == RuleDuplicate {
+			got = append(got, v)
+		}
+	}
+	want := []Violation{
+		{Path: "62.05", Field: "DataObject", Rule: RuleDuplicate, Severity: SeverityWarning, Value: "456", Message: "DataObject 62.05 should not be repeated, ignored value: 456"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got, want)
+	}
+	if len(got) > 0 && !errors.Is(got[0], ErrDuplicateTag) {
+		t.Errorf("errors.Is(%v, ErrDuplicateTag) = false", got[0])
+	}
+}
@@ -0,0 +1,145 @@
+package mpm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecode_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		target     error
+		wantPath   string
+		wantOffset int64
+	}{
+		{
+			name:       "truncated value",
+			payload:    "0002015910BEST",
+			target:     ErrTruncated,
+			wantPath:   "59",
+			wantOffset: 6,
+		},
+		{
+			name:       "invalid length",
+			payload:    "00020159AB",
+			target:     ErrInvalidFormat,
+			wantPath:   "59",
+			wantOffset: 6,
+		},
+		{
+			name:       "invalid length in template",
+			payload:    "000201620605ab12",
+			target:     &ErrInvalidLength{},
+			wantPath:   "62.05",
+			wantOffset: 10,
+		},
+		{
+			name:       "unknown tag",
+			payload:    "0002015x02ab",
+			target:     ErrUnknownTag,
+			wantPath:   "5x",
+			wantOffset: 6,
+		},
+		{
+			name:       "unknown tag in template",
+			payload:    "0002012906x10201",
+			target:     ErrUnknownTag,
+			wantPath:   "29.x1",
+			wantOffset: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.payload)
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.target)
+			}
+			var perr *ParserError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Decode() error = %T, want *ParserError", err)
+			}
+			if perr.Path != tt.wantPath || perr.Offset != tt.wantOffset {
+				t.Errorf("ParserError path = %v, offset = %v, want %v, %v", perr.Path, perr.Offset, tt.wantPath, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestErrInvalidLength(t *testing.T) {
+	_, err := Decode("000201620605ab12")
+	var lerr *ErrInvalidLength
+	if !errors.As(err, &lerr) {
+		t.Fatalf("Decode() error = %v, want *ErrInvalidLength", err)
+	}
+	if lerr.Tag != "62.05" || lerr.Offset != 10 {
+		t.Errorf("ErrInvalidLength = %+v, want tag 62.05 at offset 10", lerr)
+	}
+}
+
+func TestDecode_CRCErrorKinds(t *testing.T) {
+	body := "00020101021126160012D123456789015204541153033925802JP5906DONGRI6005TOKYO"
+	tests := []struct {
+		name    string
+		payload string
+		target  error
+	}{
+		{name: "missing", payload: body, target: &ErrMandatoryMissing{Tag: "63"}},
+		{name: "missing any tag", payload: body, target: &ErrMandatoryMissing{}},
+		{name: "mismatch", payload: body + "63040000", target: ErrCRCMismatch},
+		{name: "not last", payload: body + "6304" + checksum(body+"6304") + "0102", target: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.payload); !errors.Is(err, tt.target) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestEMVQR_Validate_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		target error
+		other  error
+	}{
+		{
+			name:   "mandatory missing",
+			modify: func(c *EMVQR) { c.MerchantName = TLV{} },
+			target: &ErrMandatoryMissing{Tag: "59"},
+			other:  &ErrMandatoryMissing{Tag: "60"},
+		},
+		{
+			name:   "invalid format",
+			modify: func(c *EMVQR) { c.SetMerchantCategoryCode("41A1") },
+			target: ErrInvalidFormat,
+			other:  ErrUnknownTag,
+		},
+		{
+			name: "unknown tag",
+			modify: func(c *EMVQR) {
+				m := new(MerchantAccountInformation)
+				m.SetGloballyUniqueIdentifier("hoge")
+				c.AddMerchantAccountInformation(ID("52"), m)
+			},
+			target: ErrUnknownTag,
+			other:  &ErrMandatoryMissing{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			for _, err := range []error{c.Validate(), c.ValidateAll().Err()} {
+				if !errors.Is(err, tt.target) {
+					t.Errorf("error = %v, want %v", err, tt.target)
+				}
+				if errors.Is(err, tt.other) {
+					t.Errorf("error = %v, should not be %v", err, tt.other)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package mpm
+
+import (
+	"regexp"
+)
+
+// Format is the data format of a data object value.
+type Format string
+
+// const ...
+const (
+	FormatNumeric             Format = "N"   // digits 0-9
+	FormatAlphanumericSpecial Format = "ans" // the common character set, 0x20-0x7E
+	FormatString              Format = "S"   // any Unicode character
+)
+
+// MaxValueLength is the largest value length a two digit length field can carry.
+const MaxValueLength = 99
+
+var (
+	amountPattern      = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+	percentagePattern  = regexp.MustCompile(`^[0-9]{1,2}(\.[0-9]{1,2})?$`)
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Match reports whether v consists only of characters allowed by f.
+func (f Format) Match(v string) bool {
+	for _, r := range v {
+		switch f {
+		case FormatNumeric:
+			if r < '0' || r > '9' {
+				return false
+			}
+		case FormatAlphanumericSpecial:
+			if r < 0x20 || r > 0x7E {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (f Format) String() string {
+	switch f {
+	case FormatNumeric:
+		return "numeric"
+	case FormatAlphanumericSpecial:
+		return "alphanumeric special"
+	}
+	return "string"
+}
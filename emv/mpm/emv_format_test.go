@@ -0,0 +1,275 @@
+package mpm
+
+import (
+	"strings"
+	"testing"
+)
+
+func validEMVQR() *EMVQR {
+	c := new(EMVQR)
+	c.SetPayloadFormatIndicator("01")
+	c.SetPointOfInitiationMethod(PointOfInitiationMethodDynamic)
+	m := new(MerchantAccountInformation)
+	m.SetGloballyUniqueIdentifier("D15600000000")
+	m.AddPaymentNetworkSpecific("05", "A93FO3230Q")
+	c.AddMerchantAccountInformation(ID("29"), m)
+	c.SetMerchantCategoryCode("4111")
+	c.SetTransactionCurrency("156")
+	c.SetTransactionAmount("23.72")
+	c.SetCountryCode("CN")
+	c.SetMerchantName("BEST TRANSPORT")
+	c.SetMerchantCity("BEIJING")
+	return c
+}
+
+func TestFormat_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		value  string
+		want   bool
+	}{
+		{name: "numeric", format: FormatNumeric, value: "0123456789", want: true},
+		{name: "numeric with letter", format: FormatNumeric, value: "12a", want: false},
+		{name: "ans", format: FormatAlphanumericSpecial, value: "Best Transport #1 (~)", want: true},
+		{name: "ans with control", format: FormatAlphanumericSpecial, value: "a\nb", want: false},
+		{name: "ans with non-ascii", format: FormatAlphanumericSpecial, value: "Café", want: false},
+		{name: "string", format: FormatString, value: "最佳运输", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Match(tt.value); got != tt.want {
+				t.Errorf("Format.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEMVQR_Validate_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *EMVQR)
+		wantErr bool
+	}{
+		{
+			name:   "ok",
+			modify: func(c *EMVQR) {},
+		},
+		{
+			name:    "payload format indicator is not 01",
+			modify:  func(c *EMVQR) { c.SetPayloadFormatIndicator("02") },
+			wantErr: true,
+		},
+		{
+			name:    "merchant category code is 3 digits",
+			modify:  func(c *EMVQR) { c.SetMerchantCategoryCode("411") },
+			wantErr: true,
+		},
+		{
+			name:    "merchant category code is not numeric",
+			modify:  func(c *EMVQR) { c.SetMerchantCategoryCode("41A1") },
+			wantErr: true,
+		},
+		{
+			name:    "transaction currency is alpha",
+			modify:  func(c *EMVQR) { c.SetTransactionCurrency("CNY") },
+			wantErr: true,
+		},
+		{
+			name:    "country code is lower case",
+			modify:  func(c *EMVQR) { c.SetCountryCode("cn") },
+			wantErr: true,
+		},
+		{
+			name:    "country code is 3 letters",
+			modify:  func(c *EMVQR) { c.SetCountryCode("CHN") },
+			wantErr: true,
+		},
+		{
+			name:    "merchant name is 26 characters",
+			modify:  func(c *EMVQR) { c.SetMerchantName(strings.Repeat("A", 26)) },
+			wantErr: true,
+		},
+		{
+			name:   "merchant name is 25 characters",
+			modify: func(c *EMVQR) { c.SetMerchantName(strings.Repeat("A", 25)) },
+		},
+		{
+			name:    "merchant name is not ans",
+			modify:  func(c *EMVQR) { c.SetMerchantName("最佳运输") },
+			wantErr: true,
+		},
+		{
+			name:    "merchant city is 16 characters",
+			modify:  func(c *EMVQR) { c.SetMerchantCity(strings.Repeat("A", 16)) },
+			wantErr: true,
+		},
+		{
+			name:    "postal code is 11 characters",
+			modify:  func(c *EMVQR) { c.SetPostalCode(strings.Repeat("1", 11)) },
+			wantErr: true,
+		},
+		{
+			name:    "transaction amount is 14 characters",
+			modify:  func(c *EMVQR) { c.SetTransactionAmount("1234567890.123") },
+			wantErr: true,
+		},
+		{
+			name:    "transaction amount uses a comma",
+			modify:  func(c *EMVQR) { c.SetTransactionAmount("23,72") },
+			wantErr: true,
+		},
+		{
+			name:    "transaction amount is negative",
+			modify:  func(c *EMVQR) { c.SetTransactionAmount("-1") },
+			wantErr: true,
+		},
+		{
+			name: "convenience fee fixed",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("02")
+				c.SetValueOfConvenienceFeeFixed("1.50")
+			},
+		},
+		{
+			name: "convenience fee fixed has trailing mark",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("02")
+				c.SetValueOfConvenienceFeeFixed("1.")
+			},
+			wantErr: true,
+		},
+		{
+			name: "convenience fee percentage",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("03")
+				c.SetValueOfConvenienceFeePercentage("99.99")
+			},
+		},
+		{
+			name: "convenience fee percentage is zero",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("03")
+				c.SetValueOfConvenienceFeePercentage("00.00")
+			},
+			wantErr: true,
+		},
+		{
+			name: "convenience fee percentage is 100",
+			modify: func(c *EMVQR) {
+				c.SetTipOrConvenienceIndicator("03")
+				c.SetValueOfConvenienceFeePercentage("100")
+			},
+			wantErr: true,
+		},
+		{
+			name: "length does not match value",
+			modify: func(c *EMVQR) {
+				c.MerchantCity.Length = "08"
+			},
+			wantErr: true,
+		},
+		{
+			name: "merchant account information without globally unique identifier",
+			modify: func(c *EMVQR) {
+				m := new(MerchantAccountInformation)
+				m.AddPaymentNetworkSpecific("01", "abcd")
+				c.AddMerchantAccountInformation(ID("30"), m)
+			},
+			wantErr: true,
+		},
+		{
+			name: "merchant account information ID out of range",
+			modify: func(c *EMVQR) {
+				m := new(MerchantAccountInformation)
+				m.SetGloballyUniqueIdentifier("hoge")
+				c.AddMerchantAccountInformation(ID("52"), m)
+			},
+			wantErr: true,
+		},
+		{
+			name: "merchant account information longer than 99",
+			modify: func(c *EMVQR) {
+				m := new(MerchantAccountInformation)
+				m.SetGloballyUniqueIdentifier("hoge")
+				m.AddPaymentNetworkSpecific("01", strings.Repeat("a", 60))
+				m.AddPaymentNetworkSpecific("02", strings.Repeat("b", 60))
+				c.AddMerchantAccountInformation(ID("30"), m)
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional data bill number is 26 characters",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetBillNumber(strings.Repeat("1", 26))
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional data consumer data request is 4 characters",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetAdditionalConsumerDataRequest("AMEA")
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional data longer than 99",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetBillNumber(strings.Repeat("1", 25))
+				a.SetStoreLabel(strings.Repeat("2", 25))
+				a.SetReferenceLabel(strings.Repeat("3", 25))
+				a.SetTerminalLabel(strings.Repeat("4", 25))
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			wantErr: true,
+		},
+		{
+			name: "language template merchant name is 26 characters",
+			modify: func(c *EMVQR) {
+				m := new(MerchantInformationLanguageTemplate)
+				m.SetLanguagePreference("ZH")
+				m.SetMerchantName(strings.Repeat("最", 26))
+				c.SetMerchantInformationLanguageTemplate(m)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unreserved template without globally unique identifier",
+			modify: func(c *EMVQR) {
+				u := new(UnreservedTemplate)
+				u.AddContextSpecificData("01", "abcd")
+				c.AddUnreservedTemplates(ID("80"), u)
+			},
+			wantErr: true,
+		},
+		{
+			name: "RFU for EMVCo ID out of range",
+			modify: func(c *EMVQR) {
+				c.AddRFUforEMVCo(ID("80"), "abcd")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("EMVQR.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncode_ValueTooLong(t *testing.T) {
+	c := validEMVQR()
+	c.SetMerchantName(strings.Repeat("A", 100))
+	if _, err := Encode(c); err == nil {
+		t.Errorf("Encode() error = nil, want error for a 3 digit length")
+	}
+}
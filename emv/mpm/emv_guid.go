@@ -0,0 +1,179 @@
+package mpm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// GUIDKind is the form of a Globally Unique Identifier.
+type GUIDKind int
+
+// const ...
+const (
+	GUIDKindUnknown       GUIDKind = iota
+	GUIDKindAID                    // RID plus optional PIX, e.g. "A000000677010111"
+	GUIDKindUUID                   // UUID without hyphens
+	GUIDKindReverseDomain          // reverse domain name, e.g. "br.gov.bcb.pix"
+)
+
+func (k GUIDKind) String() string {
+	switch k {
+	case GUIDKindAID:
+		return "AID"
+	case GUIDKindUUID:
+		return "UUID"
+	case GUIDKindReverseDomain:
+		return "reverse domain name"
+	}
+	return "unknown"
+}
+
+var (
+	aidPattern           = regexp.MustCompile(`^[ADad]([0-9A-Fa-f]{2}){4,15}[0-9A-Fa-f]$`)
+	uuidPattern          = regexp.MustCompile(`^[0-9A-Fa-f]{12}[1-5][0-9A-Fa-f]{3}[89ABab][0-9A-Fa-f]{15}$`)
+	reverseDomainPattern = regexp.MustCompile(`^[A-Za-z]{2,}(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)+$`)
+)
+
+// GUID is a Globally Unique Identifier (ID "00" of a Merchant Account
+// Information, Payment System Specific or Unreserved Template).
+type GUID struct {
+	Kind  GUIDKind
+	Value string
+}
+
+// ParseGUID classifies v as an AID (10 to 32 hex characters starting with a
+// RID), a UUID without hyphens or a reverse domain name. An AID is upper cased.
+// A 32 character value that is a valid RFC 4122 UUID is a UUID.
+func ParseGUID(v string) (GUID, error) {
+	switch {
+	case len(v) > 32:
+	case uuidPattern.MatchString(v):
+		return GUID{Kind: GUIDKindUUID, Value: v}, nil
+	case aidPattern.MatchString(v):
+		return GUID{Kind: GUIDKindAID, Value: strings.ToUpper(v)}, nil
+	case reverseDomainPattern.MatchString(v):
+		return GUID{Kind: GUIDKindReverseDomain, Value: v}, nil
+	}
+	return GUID{}, fmt.Errorf("globally unique identifier should be an AID, a UUID or a reverse domain name, globally unique identifier: %s: %w", v, ErrInvalidFormat)
+}
+
+func (g GUID) String() string {
+	return g.Value
+}
+
+// key is the form of g the registry is keyed by: domain names are not case
+// sensitive, and neither are the hex digits of a UUID.
+func (g GUID) key() string {
+	if g.Kind == GUIDKindAID {
+		return g.Value
+	}
+	return strings.ToLower(g.Value)
+}
+
+// Network returns the name of the payment network registered for g, or for
+// the RID of an AID without a registration of its own.
+func (g GUID) Network() (string, bool) {
+	guidNetworksMu.RLock()
+	defer guidNetworksMu.RUnlock()
+	if name, ok := guidNetworks[g.key()]; ok {
+		return name, true
+	}
+	if g.Kind == GUIDKindAID {
+		name, ok := guidNetworks[g.RID()]
+		return name, ok
+	}
+	return "", false
+}
+
+// RID returns the Registered Application Provider Identifier of an AID, its
+// first 10 hex characters, or "" for other kinds.
+func (g GUID) RID() string {
+	if g.Kind != GUIDKindAID {
+		return ""
+	}
+	return g.Value[:10]
+}
+
+var (
+	guidNetworksMu sync.RWMutex
+	guidNetworks   = map[string]string{}
+)
+
+func init() {
+	for guid, name := range map[string]string{
+		"A000000003":       "Visa",
+		"A000000004":       "Mastercard",
+		"A000000025":       "Amex",
+		"A000000065":       "JCB",
+		"A000000152":       "Discover",
+		"A000000333":       "UnionPay",
+		"A000000677010111": "PromptPay",
+		"A000000677010112": "PromptPay Bill Payment",
+		"A000000727":       "NAPAS VietQR",
+		"ID.CO.QRIS.WWW":   "QRIS",
+		"br.gov.bcb.pix":   "Pix",
+		"SG.PAYNOW":        "PayNow",
+		"SG.COM.NETS":      "NETS",
+		"com.p2pqrpay":     "QR Ph P2P",
+		"ph.ppmi.p2m":      "QR Ph P2M",
+	} {
+		if err := RegisterGUIDNetwork(guid, name); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterGUIDNetwork registers name as the payment network of guid,
+// replacing any previous registration.
+func RegisterGUIDNetwork(guid, name string) error {
+	g, err := ParseGUID(guid)
+	if err != nil {
+		return err
+	}
+	guidNetworksMu.Lock()
+	defer guidNetworksMu.Unlock()
+	guidNetworks[g.key()] = name
+	return nil
+}
+
+// LookupGUIDNetwork returns the name of the payment network registered for
+// guid.
+func LookupGUIDNetwork(guid string) (string, bool) {
+	g, err := ParseGUID(guid)
+	if err != nil {
+		return "", false
+	}
+	return g.Network()
+}
+
+// GUID returns the typed Globally Unique Identifier of s.
+func (s *MerchantAccountInformation) GUID() (GUID, error) {
+	return ParseGUID(s.GloballyUniqueIdentifier.Value)
+}
+
+// SetGUID sets the Globally Unique Identifier of s to g.
+func (s *MerchantAccountInformation) SetGUID(g GUID) {
+	s.SetGloballyUniqueIdentifier(g.Value)
+}
+
+// GUID returns the typed Globally Unique Identifier of s.
+func (s *PaymentSystemSpecificTemplate) GUID() (GUID, error) {
+	return ParseGUID(s.GloballyUniqueIdentifier.Value)
+}
+
+// SetGUID sets the Globally Unique Identifier of s to g.
+func (s *PaymentSystemSpecificTemplate) SetGUID(g GUID) {
+	s.SetGloballyUniqueIdentifier(g.Value)
+}
+
+// GUID returns the typed Globally Unique Identifier of s.
+func (s *UnreservedTemplate) GUID() (GUID, error) {
+	return ParseGUID(s.GloballyUniqueIdentifier.Value)
+}
+
+// SetGUID sets the Globally Unique Identifier of s to g.
+func (s *UnreservedTemplate) SetGUID(g GUID) {
+	s.SetGloballyUniqueIdentifier(g.Value)
+}
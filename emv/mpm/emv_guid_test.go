@@ -0,0 +1,95 @@
+package mpm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGUID(t *testing.T) {
+	tests := []struct {
+		v       string
+		want    GUID
+		wantErr bool
+	}{
+		{v: "A000000677010111", want: GUID{Kind: GUIDKindAID, Value: "A000000677010111"}},
+		{v: "a000000727", want: GUID{Kind: GUIDKindAID, Value: "A000000727"}},
+		{v: "D15600000000", want: GUID{Kind: GUIDKindAID, Value: "D15600000000"}},
+		{v: "3f2504e04f8911d39a0c0305e82c3301", want: GUID{Kind: GUIDKindUUID, Value: "3f2504e04f8911d39a0c0305e82c3301"}},
+		{v: "ID.CO.QRIS.WWW", want: GUID{Kind: GUIDKindReverseDomain, Value: "ID.CO.QRIS.WWW"}},
+		{v: "br.gov.bcb.pix", want: GUID{Kind: GUIDKindReverseDomain, Value: "br.gov.bcb.pix"}},
+		{v: "A00000067", wantErr: true},
+		{v: "B000000677", wantErr: true},
+		{v: "hoge", wantErr: true},
+		{v: "br..pix", wantErr: true},
+		{v: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			got, err := ParseGUID(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGUID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("ParseGUID() error = %v, want ErrInvalidFormat", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGUID() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupGUIDNetwork(t *testing.T) {
+	tests := []struct {
+		guid   string
+		want   string
+		wantOK bool
+	}{
+		{guid: "A000000677010111", want: "PromptPay", wantOK: true},
+		{guid: "a000000677010111", want: "PromptPay", wantOK: true},
+		{guid: "ID.CO.QRIS.WWW", want: "QRIS", wantOK: true},
+		{guid: "BR.GOV.BCB.PIX", want: "Pix", wantOK: true},
+		{guid: "com.example.pay", wantOK: false},
+		{guid: "hoge", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.guid, func(t *testing.T) {
+			got, ok := LookupGUIDNetwork(tt.guid)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupGUIDNetwork() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRegisterGUIDNetwork(t *testing.T) {
+	if err := RegisterGUIDNetwork("hoge", "Hoge"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("RegisterGUIDNetwork() error = %v, want ErrInvalidFormat", err)
+	}
+	if err := RegisterGUIDNetwork("com.example.wallet", "Example"); err != nil {
+		t.Fatal(err)
+	}
+	m := new(MerchantAccountInformation)
+	m.SetGloballyUniqueIdentifier("com.example.wallet")
+	g, err := m.GUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := g.Network(); got != "Example" || !ok {
+		t.Errorf("GUID.Network() = %v, %v, want Example, true", got, ok)
+	}
+}
+
+func TestEMVQR_ValidateAll_GUID(t *testing.T) {
+	c := validEMVQR()
+	m := new(MerchantAccountInformation)
+	m.SetGloballyUniqueIdentifier("hoge")
+	c.AddMerchantAccountInformation(ID("30"), m)
+	r := c.ValidateAll()
+	if err := r.Err(); err != nil {
+		t.Fatalf("ValidationReport.Err() = %v", err)
+	}
+	if len(r.Violations) != 1 || r.Violations[0].Path != "30.00" || r.Violations[0].Severity != SeverityWarning {
+		t.Errorf("EMVQR.ValidateAll() = %+v", r.Violations)
+	}
+}
@@ -0,0 +1,99 @@
+package mpm
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Transaction is the per-transaction data of a dynamic QR code. Empty fields
+// keep the value of the template.
+type Transaction struct {
+	Amount             string // Transaction Amount (ID "54")
+	BillNumber         string // Bill Number (ID "62.01")
+	StoreLabel         string // Store Label (ID "62.03")
+	ReferenceLabel     string // Reference Label (ID "62.05")
+	CustomerLabel      string // Customer Label (ID "62.06")
+	TerminalLabel      string // Terminal Label (ID "62.07")
+	PurposeTransaction string // Purpose of Transaction (ID "62.08")
+	// Vars are the values of the placeholders of the template, e.g.
+	// "amount" for "{{amount}}".
+	Vars map[string]string
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Instantiate returns a dynamic QR code built from the template c: the
+// placeholders of c are replaced with tx.Vars, the Point of Initiation
+// Method is set to "12" and the fields of tx are set. c is not modified.
+func (c *EMVQR) Instantiate(tx Transaction) (*EMVQR, error) {
+	t, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if err := substitute("", t.Nodes, tx.Vars); err != nil {
+		return nil, err
+	}
+	values := []struct {
+		path  string
+		value string
+	}{
+		{IDPointOfInitiationMethod.String(), PointOfInitiationMethodDynamic},
+		{IDTransactionAmount.String(), tx.Amount},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDBillNumber), tx.BillNumber},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDStoreLabel), tx.StoreLabel},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDReferenceLabel), tx.ReferenceLabel},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDCustomerLabel), tx.CustomerLabel},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDTerminalLabel), tx.TerminalLabel},
+		{tagPath(IDAdditionalDataFieldTemplate.String(), AdditionalIDPurposeTransaction), tx.PurposeTransaction},
+	}
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+		if err := t.Set(v.path, v.value); err != nil {
+			return nil, err
+		}
+	}
+	n, err := t.EMVQR()
+	if err != nil {
+		return nil, err
+	}
+	n.SetOrder(c.order)
+	return n, nil
+}
+
+// InstantiatePayload returns the encoded payload of c.Instantiate(tx).
+func (c *EMVQR) InstantiatePayload(tx Transaction) (string, error) {
+	n, err := c.Instantiate(tx)
+	if err != nil {
+		return "", err
+	}
+	return Encode(n)
+}
+
+// substitute replaces the placeholders in the values of nodes with vars. A
+// placeholder without a value is an error.
+func substitute(parent string, nodes []*Node, vars map[string]string) error {
+	for _, n := range nodes {
+		path := tagPath(parent, n.ID)
+		if n.IsTemplate() {
+			if err := substitute(path, n.Children, vars); err != nil {
+				return err
+			}
+			continue
+		}
+		var missing string
+		n.Value = placeholderPattern.ReplaceAllStringFunc(n.Value, func(m string) string {
+			name := placeholderPattern.FindStringSubmatch(m)[1]
+			v, ok := vars[name]
+			if !ok && missing == "" {
+				missing = name
+			}
+			return v
+		})
+		if missing != "" {
+			return fmt.Errorf("placeholder has no value. path: %s, placeholder: %s: %w", path, missing, ErrInvalidFormat)
+		}
+	}
+	return nil
+}
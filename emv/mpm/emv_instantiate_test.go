@@ -0,0 +1,89 @@
+package mpm
+
+import (
+	"errors"
+	"testing"
+)
+
+func staticTemplate() *EMVQR {
+	c := validEMVQR()
+	c.SetPointOfInitiationMethod(PointOfInitiationMethodStatic)
+	c.TransactionAmount = TLV{}
+	a := new(AdditionalDataFieldTemplate)
+	a.SetStoreLabel("{{store}}")
+	c.SetAdditionalDataFieldTemplate(a)
+	return c
+}
+
+func TestEMVQR_Instantiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *EMVQR)
+		tx      Transaction
+		want    func(c *EMVQR)
+		wantErr error
+	}{
+		{
+			name:   "transaction",
+			modify: func(c *EMVQR) {},
+			tx: Transaction{
+				Amount:         "10.00",
+				BillNumber:     "B1",
+				ReferenceLabel: "R1",
+				Vars:           map[string]string{"store": "S1"},
+			},
+			want: func(c *EMVQR) {
+				c.SetTransactionAmount("10.00")
+				a := new(AdditionalDataFieldTemplate)
+				a.SetBillNumber("B1")
+				a.SetStoreLabel("S1")
+				a.SetReferenceLabel("R1")
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+		},
+		{
+			name: "amount placeholder",
+			modify: func(c *EMVQR) {
+				c.SetTransactionAmount("{{ amount }}")
+			},
+			tx: Transaction{
+				Vars: map[string]string{"store": "S1", "amount": "5.5"},
+			},
+			want: func(c *EMVQR) {
+				c.SetTransactionAmount("5.5")
+				a := new(AdditionalDataFieldTemplate)
+				a.SetStoreLabel("S1")
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+		},
+		{
+			name:    "placeholder without value",
+			modify:  func(c *EMVQR) {},
+			tx:      Transaction{Amount: "10.00"},
+			wantErr: ErrInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := staticTemplate()
+			tt.modify(template)
+			before, _ := template.GeneratePayload()
+			got, err := template.InstantiatePayload(tt.tx)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("EMVQR.InstantiatePayload() error = %v, want %v", err, tt.wantErr)
+			}
+			if after, _ := template.GeneratePayload(); after != before {
+				t.Errorf("EMVQR.InstantiatePayload() modified the template: %v, want %v", after, before)
+			}
+			if tt.want == nil {
+				return
+			}
+			want := validEMVQR()
+			want.TransactionAmount = TLV{}
+			tt.want(want)
+			if s, err := want.GeneratePayload(); err != nil || got != s {
+				t.Errorf("EMVQR.InstantiatePayload() = %v, want %v, %v", got, s, err)
+			}
+		})
+	}
+}
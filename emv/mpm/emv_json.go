@@ -0,0 +1,215 @@
+package mpm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// UnmarshalJSON reads the JSON written by EMVQR.JSON. Tags and lengths are
+// recomputed from the values, so they may be omitted.
+func (c *EMVQR) UnmarshalJSON(data []byte) error {
+	type plain EMVQR
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	n := new(EMVQR)
+	set(n.SetPayloadFormatIndicator, p.PayloadFormatIndicator)
+	set(n.SetPointOfInitiationMethod, p.PointOfInitiationMethod)
+	for _, m := range p.MerchantAccountPrimitives {
+		n.AddMerchantAccountPrimitive(m.Tag, m.Value)
+	}
+	ids := make([]ID, 0, len(p.MerchantAccountInformation))
+	for id := range p.MerchantAccountInformation {
+		ids = append(ids, id)
+	}
+	for _, id := range n.orderIDs(ids) {
+		n.AddMerchantAccountInformation(id, p.MerchantAccountInformation[id].Value.normalized())
+	}
+	set(n.SetMerchantCategoryCode, p.MerchantCategoryCode)
+	set(n.SetTransactionCurrency, p.TransactionCurrency)
+	set(n.SetTransactionAmount, p.TransactionAmount)
+	set(n.SetTipOrConvenienceIndicator, p.TipOrConvenienceIndicator)
+	set(n.SetValueOfConvenienceFeeFixed, p.ValueOfConvenienceFeeFixed)
+	set(n.SetValueOfConvenienceFeePercentage, p.ValueOfConvenienceFeePercentage)
+	set(n.SetCountryCode, p.CountryCode)
+	set(n.SetMerchantName, p.MerchantName)
+	set(n.SetMerchantCity, p.MerchantCity)
+	set(n.SetPostalCode, p.PostalCode)
+	if p.AdditionalDataFieldTemplate != nil {
+		n.SetAdditionalDataFieldTemplate(p.AdditionalDataFieldTemplate.normalized())
+	}
+	set(n.SetCRC, p.CRC)
+	if p.MerchantInformationLanguageTemplate != nil {
+		n.SetMerchantInformationLanguageTemplate(p.MerchantInformationLanguageTemplate.normalized())
+	}
+	for _, r := range p.RFUforEMVCo {
+		n.AddRFUforEMVCo(r.Tag, r.Value)
+	}
+	ids = make([]ID, 0, len(p.UnreservedTemplates))
+	for id := range p.UnreservedTemplates {
+		ids = append(ids, id)
+	}
+	for _, id := range n.orderIDs(ids) {
+		n.AddUnreservedTemplates(id, p.UnreservedTemplates[id].Value.normalized())
+	}
+	*c = *n
+	return nil
+}
+
+// set calls setter with the value of tlv, if it has one.
+func set(setter func(string), tlv TLV) {
+	if tlv.Value != "" {
+		setter(tlv.Value)
+	}
+}
+
+func (s *MerchantAccountInformation) normalized() *MerchantAccountInformation {
+	n := new(MerchantAccountInformation)
+	if s == nil {
+		return n
+	}
+	set(n.SetGloballyUniqueIdentifier, s.GloballyUniqueIdentifier)
+	for _, t := range s.PaymentNetworkSpecific {
+		n.AddPaymentNetworkSpecific(t.Tag, t.Value)
+	}
+	return n
+}
+
+func (s *AdditionalDataFieldTemplate) normalized() *AdditionalDataFieldTemplate {
+	n := new(AdditionalDataFieldTemplate)
+	set(n.SetBillNumber, s.BillNumber)
+	set(n.SetMobileNumber, s.MobileNumber)
+	set(n.SetStoreLabel, s.StoreLabel)
+	set(n.SetLoyaltyNumber, s.LoyaltyNumber)
+	set(n.SetReferenceLabel, s.ReferenceLabel)
+	set(n.SetCustomerLabel, s.CustomerLabel)
+	set(n.SetTerminalLabel, s.TerminalLabel)
+	set(n.SetPurposeTransaction, s.PurposeTransaction)
+	set(n.SetAdditionalConsumerDataRequest, s.AdditionalConsumerDataRequest)
+	set(n.SetMerchantTaxID, s.MerchantTaxID)
+	set(n.SetMerchantChannel, s.MerchantChannel)
+	for _, t := range s.RFUforEMVCo {
+		n.AddRFUforEMVCo(t.Tag, t.Value)
+	}
+	for _, t := range s.PaymentSystemSpecific {
+		n.AddPaymentSystemSpecificTemplate(t.Tag, t.Value.normalized())
+	}
+	return n
+}
+
+func (s *MerchantInformationLanguageTemplate) normalized() *MerchantInformationLanguageTemplate {
+	n := new(MerchantInformationLanguageTemplate)
+	set(n.SetLanguagePreference, s.LanguagePreference)
+	set(n.SetMerchantName, s.MerchantName)
+	set(n.SetMerchantCity, s.MerchantCity)
+	for _, t := range s.RFUforEMVCo {
+		n.AddRFUForEMVCo(t.Tag, t.Value)
+	}
+	return n
+}
+
+func (s *PaymentSystemSpecificTemplate) normalized() *PaymentSystemSpecificTemplate {
+	n := new(PaymentSystemSpecificTemplate)
+	if s == nil {
+		return n
+	}
+	n.Raw = s.Raw
+	set(n.SetGloballyUniqueIdentifier, s.GloballyUniqueIdentifier)
+	for _, t := range s.PaymentSystemSpecific {
+		n.AddPaymentSystemSpecific(t.Tag, t.Value)
+	}
+	return n
+}
+
+func (s *UnreservedTemplate) normalized() *UnreservedTemplate {
+	n := new(UnreservedTemplate)
+	if s == nil {
+		return n
+	}
+	set(n.SetGloballyUniqueIdentifier, s.GloballyUniqueIdentifier)
+	for _, t := range s.ContextSpecificData {
+		n.AddContextSpecificData(t.Tag, t.Value)
+	}
+	return n
+}
+
+// CompactJSON returns c as compact JSON keyed by tag ID: a string for each
+// data object and an object for each template, e.g.
+//
+//	{"00":"01","29":{"00":"D15600000000"},"52":"4111","62":{"05":"REF1"}}
+//
+// The CRC is left out, as it is computed on encode. Of repeated IDs only the
+// first is kept. CompactJSONSchema describes the format.
+func (c *EMVQR) CompactJSON() string {
+	t, err := c.Tree()
+	if err != nil {
+		return ""
+	}
+	b, _ := json.Marshal(compactNodes(t.Nodes))
+	return string(b)
+}
+
+func compactNodes(nodes []*Node) map[string]interface{} {
+	m := make(map[string]interface{}, len(nodes))
+	for _, n := range nodes {
+		k := n.ID.String()
+		if _, ok := m[k]; ok || n.ID == IDCRC {
+			continue
+		}
+		if n.IsTemplate() {
+			m[k] = compactNodes(n.Children)
+			continue
+		}
+		m[k] = n.Value
+	}
+	return m
+}
+
+// ParseCompactJSON parses the JSON written by CompactJSON into an EMVQR.
+// A CRC in data is ignored.
+func ParseCompactJSON(data []byte) (*EMVQR, error) {
+	t := &Tree{}
+	if err := t.setCompact("", data); err != nil {
+		return nil, err
+	}
+	return t.EMVQR()
+}
+
+func (t *Tree) setCompact(parent string, data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidFormat)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		path := tagPath(parent, ID(k))
+		if path == IDCRC.String() {
+			continue
+		}
+		raw := bytes.TrimSpace(m[k])
+		if len(raw) > 0 && raw[0] == '{' {
+			if err := t.Set(path, ""); err != nil {
+				return err
+			}
+			if err := t.setCompact(path, raw); err != nil {
+				return err
+			}
+			continue
+		}
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("value invalid. path: %s: %w", path, ErrInvalidFormat)
+		}
+		if err := t.Set(path, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
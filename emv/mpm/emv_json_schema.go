@@ -0,0 +1,104 @@
+package mpm
+
+// CompactJSONSchema is the JSON Schema (draft-07) of the JSON written by
+// EMVQR.CompactJSON and read by ParseCompactJSON.
+const CompactJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://github.com/100x-fi/emv-qrcode/emv/mpm/compact.schema.json",
+  "title": "EMV QR Code Merchant Presented Mode",
+  "description": "An MPM payload keyed by tag ID. Templates are objects, all other data objects are strings. The CRC (ID 63) is computed on encode.",
+  "type": "object",
+  "definitions": {
+    "ans": {
+      "type": "string",
+      "pattern": "^[\\x20-\\x7E]*$"
+    },
+    "value": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 99
+    },
+    "amount": {
+      "type": "string",
+      "pattern": "^[0-9]+(\\.[0-9]+)?$",
+      "maxLength": 13
+    },
+    "template": {
+      "type": "object",
+      "patternProperties": {
+        "^[0-9]{2}$": { "$ref": "#/definitions/value" }
+      },
+      "additionalProperties": false
+    },
+    "accountTemplate": {
+      "allOf": [
+        { "$ref": "#/definitions/template" },
+        {
+          "required": ["00"],
+          "properties": {
+            "00": { "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 32 }] }
+          }
+        }
+      ]
+    }
+  },
+  "properties": {
+    "00": { "description": "Payload Format Indicator", "const": "01" },
+    "01": { "description": "Point of Initiation Method", "enum": ["11", "12"] },
+    "52": { "description": "Merchant Category Code", "type": "string", "pattern": "^[0-9]{4}$" },
+    "53": { "description": "Transaction Currency", "type": "string", "pattern": "^[0-9]{3}$" },
+    "54": { "description": "Transaction Amount", "$ref": "#/definitions/amount" },
+    "55": { "description": "Tip or Convenience Indicator", "enum": ["01", "02", "03"] },
+    "56": { "description": "Value of Convenience Fee Fixed", "$ref": "#/definitions/amount" },
+    "57": { "description": "Value of Convenience Fee Percentage", "type": "string", "pattern": "^[0-9]{1,2}(\\.[0-9]{1,2})?$" },
+    "58": { "description": "Country Code", "type": "string", "pattern": "^[A-Z]{2}$" },
+    "59": { "description": "Merchant Name", "allOf": [{ "$ref": "#/definitions/ans" }, { "minLength": 1, "maxLength": 25 }] },
+    "60": { "description": "Merchant City", "allOf": [{ "$ref": "#/definitions/ans" }, { "minLength": 1, "maxLength": 15 }] },
+    "61": { "description": "Postal Code", "allOf": [{ "$ref": "#/definitions/ans" }, { "minLength": 1, "maxLength": 10 }] },
+    "62": {
+      "description": "Additional Data Field Template",
+      "type": "object",
+      "properties": {
+        "01": { "description": "Bill Number", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "02": { "description": "Mobile Number", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "03": { "description": "Store Label", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "04": { "description": "Loyalty Number", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "05": { "description": "Reference Label", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "06": { "description": "Customer Label", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "07": { "description": "Terminal Label", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "08": { "description": "Purpose of Transaction", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 25 }] },
+        "09": { "description": "Additional Consumer Data Request", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 3 }] },
+        "10": { "description": "Merchant Tax ID", "allOf": [{ "$ref": "#/definitions/ans" }, { "maxLength": 20 }] },
+        "11": { "description": "Merchant Channel", "type": "string", "pattern": "^[0-7][0-3][0-3]$" }
+      },
+      "patternProperties": {
+        "^(1[2-9]|[2-4][0-9])$": { "description": "RFU for EMVCo", "$ref": "#/definitions/value" },
+        "^[5-9][0-9]$": { "description": "Payment System Specific Template", "$ref": "#/definitions/accountTemplate" }
+      },
+      "additionalProperties": false
+    },
+    "64": {
+      "description": "Merchant Information - Language Template",
+      "allOf": [
+        { "$ref": "#/definitions/template" },
+        {
+          "required": ["00", "01"],
+          "properties": {
+            "00": { "description": "Language Preference", "type": "string", "pattern": "^[A-Za-z]{2}$" },
+            "01": { "description": "Merchant Name", "type": "string", "maxLength": 25 },
+            "02": { "description": "Merchant City", "type": "string", "maxLength": 15 }
+          }
+        }
+      ]
+    }
+  },
+  "patternProperties": {
+    "^(0[2-9]|1[0-9]|2[0-5])$": { "description": "Merchant Account Information", "$ref": "#/definitions/value" },
+    "^(2[6-9]|[34][0-9]|5[01])$": { "description": "Merchant Account Information Template", "$ref": "#/definitions/accountTemplate" },
+    "^(6[5-9]|7[0-9])$": { "description": "RFU for EMVCo", "$ref": "#/definitions/value" },
+    "^[89][0-9]$": { "description": "Unreserved Template", "$ref": "#/definitions/accountTemplate" }
+  },
+  "additionalProperties": false,
+  "required": ["00", "52", "53", "58", "59", "60"]
+}
+`
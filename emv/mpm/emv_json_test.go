@@ -0,0 +1,142 @@
+package mpm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEMVQR_UnmarshalJSON(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(EMVQR)
+	if err := json.Unmarshal([]byte(c.JSON()), got); err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("EMVQR.Validate() error = %v", err)
+	}
+	want, err := c.GeneratePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := got.GeneratePayload(); err != nil || s != want {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", s, err, want)
+	}
+}
+
+func TestEMVQR_UnmarshalJSON_Lengths(t *testing.T) {
+	data := `{
+		"Merchant Name": {"Length": "99", "Value": "ABC"},
+		"Merchant Account Information": {"29": {"Value": {"Globally Unique Identifier": {"Value": "hoge"}}}},
+		"Additional Data Field Template": {"Mobile Number": {"Tag": "05", "Value": "0801234"}}
+	}`
+	got := new(EMVQR)
+	if err := json.Unmarshal([]byte(data), got); err != nil {
+		t.Fatal(err)
+	}
+	want := new(EMVQR)
+	m := new(MerchantAccountInformation)
+	m.SetGloballyUniqueIdentifier("hoge")
+	want.AddMerchantAccountInformation("29", m)
+	want.SetMerchantName("ABC")
+	a := new(AdditionalDataFieldTemplate)
+	a.SetMobileNumber("0801234")
+	want.SetAdditionalDataFieldTemplate(a)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEMVQR_JSON_MobileNumber(t *testing.T) {
+	c := validEMVQR()
+	a := new(AdditionalDataFieldTemplate)
+	a.SetMobileNumber("0801234")
+	c.SetAdditionalDataFieldTemplate(a)
+	if s := c.JSON(); !strings.Contains(s, `"Mobile Number":{"Tag":"02","Length":"07","Value":"0801234"}`) {
+		t.Errorf("EMVQR.JSON() = %v, want Mobile Number", s)
+	}
+}
+
+func TestEMVQR_CompactJSON(t *testing.T) {
+	c := validEMVQR()
+	a := new(AdditionalDataFieldTemplate)
+	a.SetReferenceLabel("REF1")
+	c.SetAdditionalDataFieldTemplate(a)
+	want := `{"00":"01","01":"12","29":{"00":"D15600000000","05":"A93FO3230Q"},"52":"4111","53":"156","54":"23.72","58":"CN","59":"BEST TRANSPORT","60":"BEIJING","62":{"05":"REF1"}}`
+	if got := c.CompactJSON(); got != want {
+		t.Errorf("EMVQR.CompactJSON() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCompactJSON(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseCompactJSON([]byte(c.CompactJSON()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := c.GeneratePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := got.GeneratePayload(); err != nil || s != want {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", s, err, want)
+	}
+}
+
+func TestParseCompactJSON_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "not an object", data: `[]`, want: ErrInvalidFormat},
+		{name: "number value", data: `{"59": 1}`, want: ErrInvalidFormat},
+		{name: "invalid id", data: `{"590": "ABC"}`, want: ErrInvalidFormat},
+		{name: "object for a data object", data: `{"59": {"01": "ABC"}}`, want: ErrNotTemplate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCompactJSON([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("ParseCompactJSON() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactJSONSchema(t *testing.T) {
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(CompactJSONSchema), &v); err != nil {
+		t.Fatalf("CompactJSONSchema is not JSON: %v", err)
+	}
+}
+
+func TestEMVQR_JSON_PaymentSystemSpecificRaw(t *testing.T) {
+	body := "000201010212" + format("29", "0012D156000000000510A93FO3230Q") + "520441115303156" + "5802CN5914BEST TRANSPORT6007BEIJING"
+	c, err := Decode(withCRC(body + "62095005hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := c.JSON()
+	if !strings.Contains(s, `"Raw":"hello"`) {
+		t.Errorf("EMVQR.JSON() = %v, want the raw Payment System Specific value", s)
+	}
+	got := new(EMVQR)
+	if err := json.Unmarshal([]byte(s), got); err != nil {
+		t.Fatal(err)
+	}
+	want, err := c.GeneratePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := got.GeneratePayload(); err != nil || s != want {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", s, err, want)
+	}
+}
@@ -0,0 +1,281 @@
+package mpm
+
+import (
+	"strings"
+)
+
+// Language is an ISO 639-1 language.
+type Language struct {
+	Code   string // ISO 639-1 code, the value of ID "64.00", e.g. "th"
+	Alpha3 string // ISO 639-2/T code, e.g. "tha"
+	Name   string // English name, e.g. "Thai"
+}
+
+// languages is the ISO 639-1 list of two letter codes.
+var languages = []Language{
+	{Code: "aa", Alpha3: "aar", Name: "Afar"},
+	{Code: "ab", Alpha3: "abk", Name: "Abkhazian"},
+	{Code: "ae", Alpha3: "ave", Name: "Avestan"},
+	{Code: "af", Alpha3: "afr", Name: "Afrikaans"},
+	{Code: "ak", Alpha3: "aka", Name: "Akan"},
+	{Code: "am", Alpha3: "amh", Name: "Amharic"},
+	{Code: "an", Alpha3: "arg", Name: "Aragonese"},
+	{Code: "ar", Alpha3: "ara", Name: "Arabic"},
+	{Code: "as", Alpha3: "asm", Name: "Assamese"},
+	{Code: "av", Alpha3: "ava", Name: "Avaric"},
+	{Code: "ay", Alpha3: "aym", Name: "Aymara"},
+	{Code: "az", Alpha3: "aze", Name: "Azerbaijani"},
+	{Code: "ba", Alpha3: "bak", Name: "Bashkir"},
+	{Code: "be", Alpha3: "bel", Name: "Belarusian"},
+	{Code: "bg", Alpha3: "bul", Name: "Bulgarian"},
+	{Code: "bi", Alpha3: "bis", Name: "Bislama"},
+	{Code: "bm", Alpha3: "bam", Name: "Bambara"},
+	{Code: "bn", Alpha3: "ben", Name: "Bengali"},
+	{Code: "bo", Alpha3: "bod", Name: "Tibetan"},
+	{Code: "br", Alpha3: "bre", Name: "Breton"},
+	{Code: "bs", Alpha3: "bos", Name: "Bosnian"},
+	{Code: "ca", Alpha3: "cat", Name: "Catalan"},
+	{Code: "ce", Alpha3: "che", Name: "Chechen"},
+	{Code: "ch", Alpha3: "cha", Name: "Chamorro"},
+	{Code: "co", Alpha3: "cos", Name: "Corsican"},
+	{Code: "cr", Alpha3: "cre", Name: "Cree"},
+	{Code: "cs", Alpha3: "ces", Name: "Czech"},
+	{Code: "cu", Alpha3: "chu", Name: "Church Slavic"},
+	{Code: "cv", Alpha3: "chv", Name: "Chuvash"},
+	{Code: "cy", Alpha3: "cym", Name: "Welsh"},
+	{Code: "da", Alpha3: "dan", Name: "Danish"},
+	{Code: "de", Alpha3: "deu", Name: "German"},
+	{Code: "dv", Alpha3: "div", Name: "Divehi"},
+	{Code: "dz", Alpha3: "dzo", Name: "Dzongkha"},
+	{Code: "ee", Alpha3: "ewe", Name: "Ewe"},
+	{Code: "el", Alpha3: "ell", Name: "Greek"},
+	{Code: "en", Alpha3: "eng", Name: "English"},
+	{Code: "eo", Alpha3: "epo", Name: "Esperanto"},
+	{Code: "es", Alpha3: "spa", Name: "Spanish"},
+	{Code: "et", Alpha3: "est", Name: "Estonian"},
+	{Code: "eu", Alpha3: "eus", Name: "Basque"},
+	{Code: "fa", Alpha3: "fas", Name: "Persian"},
+	{Code: "ff", Alpha3: "ful", Name: "Fulah"},
+	{Code: "fi", Alpha3: "fin", Name: "Finnish"},
+	{Code: "fj", Alpha3: "fij", Name: "Fijian"},
+	{Code: "fo", Alpha3: "fao", Name: "Faroese"},
+	{Code: "fr", Alpha3: "fra", Name: "French"},
+	{Code: "fy", Alpha3: "fry", Name: "Western Frisian"},
+	{Code: "ga", Alpha3: "gle", Name: "Irish"},
+	{Code: "gd", Alpha3: "gla", Name: "Gaelic"},
+	{Code: "gl", Alpha3: "glg", Name: "Galician"},
+	{Code: "gn", Alpha3: "grn", Name: "Guarani"},
+	{Code: "gu", Alpha3: "guj", Name: "Gujarati"},
+	{Code: "gv", Alpha3: "glv", Name: "Manx"},
+	{Code: "ha", Alpha3: "hau", Name: "Hausa"},
+	{Code: "he", Alpha3: "heb", Name: "Hebrew"},
+	{Code: "hi", Alpha3: "hin", Name: "Hindi"},
+	{Code: "ho", Alpha3: "hmo", Name: "Hiri Motu"},
+	{Code: "hr", Alpha3: "hrv", Name: "Croatian"},
+	{Code: "ht", Alpha3: "hat", Name: "Haitian"},
+	{Code: "hu", Alpha3: "hun", Name: "Hungarian"},
+	{Code: "hy", Alpha3: "hye", Name: "Armenian"},
+	{Code: "hz", Alpha3: "her", Name: "Herero"},
+	{Code: "ia", Alpha3: "ina", Name: "Interlingua"},
+	{Code: "id", Alpha3: "ind", Name: "Indonesian"},
+	{Code: "ie", Alpha3: "ile", Name: "Interlingue"},
+	{Code: "ig", Alpha3: "ibo", Name: "Igbo"},
+	{Code: "ii", Alpha3: "iii", Name: "Sichuan Yi"},
+	{Code: "ik", Alpha3: "ipk", Name: "Inupiaq"},
+	{Code: "io", Alpha3: "ido", Name: "Ido"},
+	{Code: "is", Alpha3: "isl", Name: "Icelandic"},
+	{Code: "it", Alpha3: "ita", Name: "Italian"},
+	{Code: "iu", Alpha3: "iku", Name: "Inuktitut"},
+	{Code: "ja", Alpha3: "jpn", Name: "Japanese"},
+	{Code: "jv", Alpha3: "jav", Name: "Javanese"},
+	{Code: "ka", Alpha3: "kat", Name: "Georgian"},
+	{Code: "kg", Alpha3: "kon", Name: "Kongo"},
+	{Code: "ki", Alpha3: "kik", Name: "Kikuyu"},
+	{Code: "kj", Alpha3: "kua", Name: "Kuanyama"},
+	{Code: "kk", Alpha3: "kaz", Name: "Kazakh"},
+	{Code: "kl", Alpha3: "kal", Name: "Kalaallisut"},
+	{Code: "km", Alpha3: "khm", Name: "Central Khmer"},
+	{Code: "kn", Alpha3: "kan", Name: "Kannada"},
+	{Code: "ko", Alpha3: "kor", Name: "Korean"},
+	{Code: "kr", Alpha3: "kau", Name: "Kanuri"},
+	{Code: "ks", Alpha3: "kas", Name: "Kashmiri"},
+	{Code: "ku", Alpha3: "kur", Name: "Kurdish"},
+	{Code: "kv", Alpha3: "kom", Name: "Komi"},
+	{Code: "kw", Alpha3: "cor", Name: "Cornish"},
+	{Code: "ky", Alpha3: "kir", Name: "Kirghiz"},
+	{Code: "la", Alpha3: "lat", Name: "Latin"},
+	{Code: "lb", Alpha3: "ltz", Name: "Luxembourgish"},
+	{Code: "lg", Alpha3: "lug", Name: "Ganda"},
+	{Code: "li", Alpha3: "lim", Name: "Limburgan"},
+	{Code: "ln", Alpha3: "lin", Name: "Lingala"},
+	{Code: "lo", Alpha3: "lao", Name: "Lao"},
+	{Code: "lt", Alpha3: "lit", Name: "Lithuanian"},
+	{Code: "lu", Alpha3: "lub", Name: "Luba-Katanga"},
+	{Code: "lv", Alpha3: "lav", Name: "Latvian"},
+	{Code: "mg", Alpha3: "mlg", Name: "Malagasy"},
+	{Code: "mh", Alpha3: "mah", Name: "Marshallese"},
+	{Code: "mi", Alpha3: "mri", Name: "Maori"},
+	{Code: "mk", Alpha3: "mkd", Name: "Macedonian"},
+	{Code: "ml", Alpha3: "mal", Name: "Malayalam"},
+	{Code: "mn", Alpha3: "mon", Name: "Mongolian"},
+	{Code: "mr", Alpha3: "mar", Name: "Marathi"},
+	{Code: "ms", Alpha3: "msa", Name: "Malay"},
+	{Code: "mt", Alpha3: "mlt", Name: "Maltese"},
+	{Code: "my", Alpha3: "mya", Name: "Burmese"},
+	{Code: "na", Alpha3: "nau", Name: "Nauru"},
+	{Code: "nb", Alpha3: "nob", Name: "Norwegian Bokmål"},
+	{Code: "nd", Alpha3: "nde", Name: "North Ndebele"},
+	{Code: "ne", Alpha3: "nep", Name: "Nepali"},
+	{Code: "ng", Alpha3: "ndo", Name: "Ndonga"},
+	{Code: "nl", Alpha3: "nld", Name: "Dutch"},
+	{Code: "nn", Alpha3: "nno", Name: "Norwegian Nynorsk"},
+	{Code: "no", Alpha3: "nor", Name: "Norwegian"},
+	{Code: "nr", Alpha3: "nbl", Name: "South Ndebele"},
+	{Code: "nv", Alpha3: "nav", Name: "Navajo"},
+	{Code: "ny", Alpha3: "nya", Name: "Chichewa"},
+	{Code: "oc", Alpha3: "oci", Name: "Occitan"},
+	{Code: "oj", Alpha3: "oji", Name: "Ojibwa"},
+	{Code: "om", Alpha3: "orm", Name: "Oromo"},
+	{Code: "or", Alpha3: "ori", Name: "Oriya"},
+	{Code: "os", Alpha3: "oss", Name: "Ossetian"},
+	{Code: "pa", Alpha3: "pan", Name: "Punjabi"},
+	{Code: "pi", Alpha3: "pli", Name: "Pali"},
+	{Code: "pl", Alpha3: "pol", Name: "Polish"},
+	{Code: "ps", Alpha3: "pus", Name: "Pashto"},
+	{Code: "pt", Alpha3: "por", Name: "Portuguese"},
+	{Code: "qu", Alpha3: "que", Name: "Quechua"},
+	{Code: "rm", Alpha3: "roh", Name: "Romansh"},
+	{Code: "rn", Alpha3: "run", Name: "Rundi"},
+	{Code: "ro", Alpha3: "ron", Name: "Romanian"},
+	{Code: "ru", Alpha3: "rus", Name: "Russian"},
+	{Code: "rw", Alpha3: "kin", Name: "Kinyarwanda"},
+	{Code: "sa", Alpha3: "san", Name: "Sanskrit"},
+	{Code: "sc", Alpha3: "srd", Name: "Sardinian"},
+	{Code: "sd", Alpha3: "snd", Name: "Sindhi"},
+	{Code: "se", Alpha3: "sme", Name: "Northern Sami"},
+	{Code: "sg", Alpha3: "sag", Name: "Sango"},
+	{Code: "si", Alpha3: "sin", Name: "Sinhala"},
+	{Code: "sk", Alpha3: "slk", Name: "Slovak"},
+	{Code: "sl", Alpha3: "slv", Name: "Slovenian"},
+	{Code: "sm", Alpha3: "smo", Name: "Samoan"},
+	{Code: "sn", Alpha3: "sna", Name: "Shona"},
+	{Code: "so", Alpha3: "som", Name: "Somali"},
+	{Code: "sq", Alpha3: "sqi", Name: "Albanian"},
+	{Code: "sr", Alpha3: "srp", Name: "Serbian"},
+	{Code: "ss", Alpha3: "ssw", Name: "Swati"},
+	{Code: "st", Alpha3: "sot", Name: "Southern Sotho"},
+	{Code: "su", Alpha3: "sun", Name: "Sundanese"},
+	{Code: "sv", Alpha3: "swe", Name: "Swedish"},
+	{Code: "sw", Alpha3: "swa", Name: "Swahili"},
+	{Code: "ta", Alpha3: "tam", Name: "Tamil"},
+	{Code: "te", Alpha3: "tel", Name: "Telugu"},
+	{Code: "tg", Alpha3: "tgk", Name: "Tajik"},
+	{Code: "th", Alpha3: "tha", Name: "Thai"},
+	{Code: "ti", Alpha3: "tir", Name: "Tigrinya"},
+	{Code: "tk", Alpha3: "tuk", Name: "Turkmen"},
+	{Code: "tl", Alpha3: "tgl", Name: "Tagalog"},
+	{Code: "tn", Alpha3: "tsn", Name: "Tswana"},
+	{Code: "to", Alpha3: "ton", Name: "Tonga"},
+	{Code: "tr", Alpha3: "tur", Name: "Turkish"},
+	{Code: "ts", Alpha3: "tso", Name: "Tsonga"},
+	{Code: "tt", Alpha3: "tat", Name: "Tatar"},
+	{Code: "tw", Alpha3: "twi", Name: "Twi"},
+	{Code: "ty", Alpha3: "tah", Name: "Tahitian"},
+	{Code: "ug", Alpha3: "uig", Name: "Uighur"},
+	{Code: "uk", Alpha3: "ukr", Name: "Ukrainian"},
+	{Code: "ur", Alpha3: "urd", Name: "Urdu"},
+	{Code: "uz", Alpha3: "uzb", Name: "Uzbek"},
+	{Code: "ve", Alpha3: "ven", Name: "Venda"},
+	{Code: "vi", Alpha3: "vie", Name: "Vietnamese"},
+	{Code: "vo", Alpha3: "vol", Name: "Volapük"},
+	{Code: "wa", Alpha3: "wln", Name: "Walloon"},
+	{Code: "wo", Alpha3: "wol", Name: "Wolof"},
+	{Code: "xh", Alpha3: "xho", Name: "Xhosa"},
+	{Code: "yi", Alpha3: "yid", Name: "Yiddish"},
+	{Code: "yo", Alpha3: "yor", Name: "Yoruba"},
+	{Code: "za", Alpha3: "zha", Name: "Zhuang"},
+	{Code: "zh", Alpha3: "zho", Name: "Chinese"},
+	{Code: "zu", Alpha3: "zul", Name: "Zulu"},
+}
+
+// languageAliases maps the ISO 639-2/B codes that differ from the /T codes,
+// and the deprecated codes still used by some platforms, to ISO 639-1.
+var languageAliases = map[string]string{
+	"alb": "sq", "arm": "hy", "baq": "eu", "bur": "my", "chi": "zh",
+	"cze": "cs", "dut": "nl", "fre": "fr", "geo": "ka", "ger": "de",
+	"gre": "el", "ice": "is", "mac": "mk", "mao": "mi", "may": "ms",
+	"per": "fa", "rum": "ro", "slo": "sk", "tib": "bo", "wel": "cy",
+	"in": "id", "iw": "he", "ji": "yi",
+}
+
+var languagesByCode = func() map[string]Language {
+	m := make(map[string]Language, 2*len(languages))
+	for _, l := range languages {
+		m[l.Code] = l
+		m[l.Alpha3] = l
+	}
+	for alias, code := range languageAliases {
+		m[alias] = m[code]
+	}
+	return m
+}()
+
+// Languages returns the ISO 639-1 languages, ordered by code.
+func Languages() []Language {
+	return append([]Language(nil), languages...)
+}
+
+// LookupLanguage returns the language of tag: an ISO 639-1 or 639-2 code, or
+// a BCP 47 language tag such as "zh-Hant-TW", of which only the primary
+// language subtag is used. Case is ignored.
+func LookupLanguage(tag string) (Language, bool) {
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	l, ok := languagesByCode[strings.ToLower(tag)]
+	return l, ok
+}
+
+// validLanguagePreference reports whether v is an ISO 639-1 code.
+func validLanguagePreference(v string) bool {
+	l, ok := languagesByCode[strings.ToLower(v)]
+	return ok && strings.EqualFold(l.Code, v)
+}
+
+// MerchantDisplay is the merchant name and city to show to the consumer.
+type MerchantDisplay struct {
+	Name string
+	City string
+	// Language is the Language Preference (ID "64.00") the name is in, or
+	// "" for the Merchant Name (ID "59") and Merchant City (ID "60").
+	Language string
+}
+
+// MerchantDisplay returns the merchant name and city of c for a device that
+// prefers the languages preferred, most preferred first. The Merchant
+// Information - Language Template (ID "64") is used if its Language
+// Preference matches one of them, otherwise the Merchant Name and Merchant
+// City, which carry no language. A Merchant City missing from ID "64" falls
+// back to ID "60".
+func (c *EMVQR) MerchantDisplay(preferred []string) MerchantDisplay {
+	d := MerchantDisplay{Name: c.MerchantName.Value, City: c.MerchantCity.Value}
+	m := c.MerchantInformationLanguageTemplate
+	if m == nil || m.MerchantName.Value == "" {
+		return d
+	}
+	lang, ok := LookupLanguage(m.LanguagePreference.Value)
+	if !ok {
+		return d
+	}
+	for _, p := range preferred {
+		if l, ok := LookupLanguage(p); ok && l.Code == lang.Code {
+			d.Name = m.MerchantName.Value
+			if m.MerchantCity.Value != "" {
+				d.City = m.MerchantCity.Value
+			}
+			d.Language = m.LanguagePreference.Value
+			return d
+		}
+	}
+	return d
+}
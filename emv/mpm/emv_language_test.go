@@ -0,0 +1,114 @@
+package mpm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupLanguage(t *testing.T) {
+	thai := Language{Code: "th", Alpha3: "tha", Name: "Thai"}
+	chinese := Language{Code: "zh", Alpha3: "zho", Name: "Chinese"}
+	tests := []struct {
+		tag    string
+		want   Language
+		wantOK bool
+	}{
+		{tag: "th", want: thai, wantOK: true},
+		{tag: "TH", want: thai, wantOK: true},
+		{tag: "th-TH", want: thai, wantOK: true},
+		{tag: "tha", want: thai, wantOK: true},
+		{tag: "zh-Hant-TW", want: chinese, wantOK: true},
+		{tag: "zh_CN", want: chinese, wantOK: true},
+		{tag: "chi", want: chinese, wantOK: true},
+		{tag: "iw", want: Language{Code: "he", Alpha3: "heb", Name: "Hebrew"}, wantOK: true},
+		{tag: "xx", wantOK: false},
+		{tag: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := LookupLanguage(tt.tag)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+				t.Errorf("LookupLanguage() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEMVQR_MerchantDisplay(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		modify    func(c *EMVQR)
+		want      MerchantDisplay
+	}{
+		{
+			name:      "language template",
+			preferred: []string{"zh-Hans-CN", "en"},
+			want:      MerchantDisplay{Name: "最佳运输", City: "北京", Language: "ZH"},
+		},
+		{
+			name:      "later preference",
+			preferred: []string{"ja", "zho"},
+			want:      MerchantDisplay{Name: "最佳运输", City: "北京", Language: "ZH"},
+		},
+		{
+			name:      "fallback",
+			preferred: []string{"th-TH", "en-US"},
+			want:      MerchantDisplay{Name: "BEST TRANSPORT", City: "BEIJING"},
+		},
+		{
+			name:      "city fallback",
+			preferred: []string{"th"},
+			modify: func(c *EMVQR) {
+				m := new(MerchantInformationLanguageTemplate)
+				m.SetLanguagePreference("TH")
+				m.SetMerchantName("ขนส่งที่ดีที่สุด")
+				c.SetMerchantInformationLanguageTemplate(m)
+			},
+			want: MerchantDisplay{Name: "ขนส่งที่ดีที่สุด", City: "BEIJING", Language: "TH"},
+		},
+		{
+			name:      "no language template",
+			preferred: []string{"zh"},
+			modify: func(c *EMVQR) {
+				c.MerchantInformationLanguageTemplate = nil
+			},
+			want: MerchantDisplay{Name: "BEST TRANSPORT", City: "BEIJING"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Decode(samplePayload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.modify != nil {
+				tt.modify(c)
+			}
+			if got := c.MerchantDisplay(tt.preferred); got != tt.want {
+				t.Errorf("EMVQR.MerchantDisplay() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEMVQR_ValidateAll_LanguagePreference(t *testing.T) {
+	c := validEMVQR()
+	m := new(MerchantInformationLanguageTemplate)
+	m.SetLanguagePreference("XX")
+	m.SetMerchantName("BEST")
+	c.SetMerchantInformationLanguageTemplate(m)
+	want := []Violation{
+		{
+			Path:     "64.00",
+			Field:    "LanguagePreference",
+			Rule:     RuleValue,
+			Severity: SeverityError,
+			Value:    "XX",
+			Message:  "LanguagePreference should be an ISO 639-1 code, LanguagePreference: XX",
+		},
+	}
+	if got := c.ValidateAll(); !reflect.DeepEqual(got.Violations, want) {
+		t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, want)
+	}
+}
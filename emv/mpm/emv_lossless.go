@@ -0,0 +1,76 @@
+package mpm
+
+// layout records the data objects of a template in the order a lossless
+// decode found them. Data objects that have no field of their own (IDs that
+// are not numeric, empty values, repeated IDs the DuplicatePolicy ignores and
+// templates that do not parse) are kept verbatim.
+type layout struct {
+	entries []layoutEntry
+}
+
+type layoutEntry struct {
+	id       ID
+	verbatim string // the whole data object, if it has no field of its own
+}
+
+// dataObject is an encoded data object of a template.
+type dataObject struct {
+	id ID
+	s  string
+}
+
+func tlvObjects(tlvs ...TLV) []dataObject {
+	objects := make([]dataObject, 0, len(tlvs))
+	for _, tlv := range tlvs {
+		objects = append(objects, dataObject{id: tlv.Tag, s: tlv.String()})
+	}
+	return objects
+}
+
+// keep records the data object id with value, which the DuplicatePolicy
+// decodes if decode is true. It returns true if the data object was kept
+// verbatim, and false if it should be decoded into its field.
+func (l *layout) keep(id ID, value string, decode bool) bool {
+	_, err := id.ParseInt()
+	if err != nil || value == "" || !decode {
+		l.entries = append(l.entries, layoutEntry{id: id, verbatim: format(id, value)})
+		return true
+	}
+	l.entries = append(l.entries, layoutEntry{id: id})
+	return false
+}
+
+// keepLast turns the last recorded data object into a verbatim one, for a
+// template value that could not be decoded.
+func (l *layout) keepLast(id ID, value string) {
+	l.entries[len(l.entries)-1].verbatim = format(id, value)
+}
+
+// encode concatenates objects. With a layout, the data objects recorded by
+// the decode come first, in their original position, followed by those
+// added since.
+func (l *layout) encode(objects []dataObject) string {
+	s := ""
+	used := make([]bool, len(objects))
+	if l != nil {
+		for _, e := range l.entries {
+			if e.verbatim != "" {
+				s += e.verbatim
+				continue
+			}
+			for i, o := range objects {
+				if !used[i] && o.id == e.id {
+					used[i] = true
+					s += o.s
+					break
+				}
+			}
+		}
+	}
+	for i, o := range objects {
+		if !used[i] {
+			s += o.s
+		}
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package mpm
+
+import (
+	"testing"
+)
+
+func withCRC(body string) string {
+	return body + "6304" + checksum(body+"6304")
+}
+
+func TestParseEMVQRWithOptions_Lossless(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{
+			name:    "sample",
+			payload: "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304A13A",
+		},
+		{
+			name:    "fields out of order",
+			payload: withCRC("000201520441115802CN5903ABC6005TOKYO5303392" + format("26", "00041234"+"0102ab")),
+		},
+		{
+			name:    "non-numeric ids",
+			payload: withCRC("000201AB04test" + format("26", "0004hoge"+"0x03abc") + "5802JP"),
+		},
+		{
+			name:    "repeated ids",
+			payload: withCRC("0002015903ABC5903DEF" + format("62", "0503123"+"0503456") + format("26", "00041234") + format("26", "00045678")),
+		},
+		{
+			name:    "empty values",
+			payload: withCRC("00020159006000620064005802JP"),
+		},
+		{
+			name:    "globally unique identifier last",
+			payload: withCRC("000201" + format("29", "01041234"+"00041234") + format("81", "0102ab"+"00041234")),
+		},
+		{
+			name:    "templates that do not parse",
+			payload: withCRC("0002012603abc6205x12346403000"),
+		},
+		{
+			name:    "language template",
+			payload: withCRC("000201" + format("64", "0202北京"+"0002ZH"+"0104最佳运输"+"9902ab")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEMVQRWithOptions(tt.payload, DecodeOptions{Lossless: true, Duplicates: DuplicateKeepFirst})
+			if err != nil {
+				t.Fatalf("ParseEMVQRWithOptions() error = %v", err)
+			}
+			if s, err := got.GeneratePayload(); err != nil || s != tt.payload {
+				t.Errorf("GeneratePayload() = %v, %v, want %v", s, err, tt.payload)
+			}
+		})
+	}
+}
+
+func TestParseEMVQRWithOptions_LosslessEdit(t *testing.T) {
+	payload := withCRC("000201AB04test5903ABC6005TOKYO" + format("62", "0503123") + "6203123")
+	got, err := ParseEMVQRWithOptions(payload, DecodeOptions{Lossless: true, Duplicates: DuplicateKeepFirst})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.SetMerchantName("XYZ")
+	got.MerchantCity = TLV{}
+	got.AdditionalDataFieldTemplate.SetBillNumber("9")
+	got.SetCountryCode("JP")
+	want := withCRC("000201AB04test5903XYZ" + format("62", "0503123"+"01019") + "6203123" + "5802JP")
+	if s, err := got.GeneratePayload(); err != nil || s != want {
+		t.Errorf("GeneratePayload() = %v, %v, want %v", s, err, want)
+	}
+}
+
+func TestParseEMVQRWithOptions_NotLossless(t *testing.T) {
+	if _, err := ParseEMVQRWithOptions(withCRC("000201AB04test"), DecodeOptions{}); err == nil {
+		t.Errorf("ParseEMVQRWithOptions() error = nil, want error for a non-numeric ID")
+	}
+}
@@ -0,0 +1,412 @@
+package mpm
+
+// MerchantCategory is an ISO 18245 merchant category.
+type MerchantCategory struct {
+	Code        string // the value of ID "52", e.g. "5411"
+	Description string // e.g. "Grocery Stores, Supermarkets"
+	Group       string // e.g. "Retail Outlet Services"
+}
+
+// MCCRange is an inclusive range of Merchant Category Codes.
+type MCCRange struct {
+	Start string
+	End   string
+}
+
+// Contains reports whether the Merchant Category Code code is in r.
+func (r MCCRange) Contains(code string) bool {
+	return len(code) == 4 && FormatNumeric.Match(code) && r.Start <= code && code <= r.End
+}
+
+// mccGroup is a range of Merchant Category Codes with a common use. Codes of
+// a brand group are assigned to individual airlines, car rental agencies and
+// hotels; they are described by the group.
+type mccGroup struct {
+	MCCRange
+	name  string
+	brand bool
+}
+
+var mccGroups = []mccGroup{
+	{MCCRange: MCCRange{"0001", "1499"}, name: "Agricultural Services"},
+	{MCCRange: MCCRange{"1500", "2999"}, name: "Contracted Services"},
+	{MCCRange: MCCRange{"3000", "3299"}, name: "Airlines", brand: true},
+	{MCCRange: MCCRange{"3300", "3499"}, name: "Car Rental", brand: true},
+	{MCCRange: MCCRange{"3500", "3999"}, name: "Lodging", brand: true},
+	{MCCRange: MCCRange{"4000", "4799"}, name: "Transportation Services"},
+	{MCCRange: MCCRange{"4800", "4999"}, name: "Utility Services"},
+	{MCCRange: MCCRange{"5000", "5599"}, name: "Retail Outlet Services"},
+	{MCCRange: MCCRange{"5600", "5699"}, name: "Clothing Stores"},
+	{MCCRange: MCCRange{"5700", "7299"}, name: "Miscellaneous Stores"},
+	{MCCRange: MCCRange{"7300", "7999"}, name: "Business Services"},
+	{MCCRange: MCCRange{"8000", "8999"}, name: "Professional Services and Membership Organizations"},
+	{MCCRange: MCCRange{"9000", "9999"}, name: "Government Services"},
+}
+
+// merchantCategories is the ISO 18245 list of Merchant Category Codes in
+// general use, without the brand codes.
+var merchantCategories = withGroups([]MerchantCategory{
+	{Code: "0742", Description: "Veterinary Services"},
+	{Code: "0763", Description: "Agricultural Cooperatives"},
+	{Code: "0780", Description: "Landscaping and Horticultural Services"},
+	{Code: "1520", Description: "General Contractors - Residential and Commercial"},
+	{Code: "1711", Description: "Heating, Plumbing, and Air Conditioning Contractors"},
+	{Code: "1731", Description: "Electrical Contractors"},
+	{Code: "1740", Description: "Masonry, Stonework, Tile Setting, Plastering, and Insulation Contractors"},
+	{Code: "1750", Description: "Carpentry Contractors"},
+	{Code: "1761", Description: "Roofing, Siding, and Sheet Metal Work Contractors"},
+	{Code: "1771", Description: "Concrete Work Contractors"},
+	{Code: "1799", Description: "Special Trade Contractors"},
+	{Code: "2741", Description: "Miscellaneous Publishing and Printing"},
+	{Code: "2791", Description: "Typesetting, Platemaking, and Related Services"},
+	{Code: "2842", Description: "Specialty Cleaning, Polishing, and Sanitation Preparations"},
+	{Code: "4011", Description: "Railroads"},
+	{Code: "4111", Description: "Local and Suburban Commuter Passenger Transportation, Including Ferries"},
+	{Code: "4112", Description: "Passenger Railways"},
+	{Code: "4119", Description: "Ambulance Services"},
+	{Code: "4121", Description: "Taxicabs and Limousines"},
+	{Code: "4131", Description: "Bus Lines"},
+	{Code: "4214", Description: "Motor Freight Carriers and Trucking"},
+	{Code: "4215", Description: "Courier Services"},
+	{Code: "4225", Description: "Public Warehousing and Storage"},
+	{Code: "4411", Description: "Steamship and Cruise Lines"},
+	{Code: "4457", Description: "Boat Rentals and Leasing"},
+	{Code: "4468", Description: "Marinas, Marine Service, and Supplies"},
+	{Code: "4511", Description: "Airlines and Air Carriers"},
+	{Code: "4582", Description: "Airports, Flying Fields, and Airport Terminals"},
+	{Code: "4722", Description: "Travel Agencies and Tour Operators"},
+	{Code: "4784", Description: "Tolls and Bridge Fees"},
+	{Code: "4789", Description: "Transportation Services"},
+	{Code: "4812", Description: "Telecommunication Equipment and Telephone Sales"},
+	{Code: "4813", Description: "Special Telecom Merchant"},
+	{Code: "4814", Description: "Telecommunication Services"},
+	{Code: "4815", Description: "Monthly Summary Telephone Charges"},
+	{Code: "4816", Description: "Computer Network and Information Services"},
+	{Code: "4821", Description: "Telegraph Services"},
+	{Code: "4829", Description: "Money Transfer"},
+	{Code: "4899", Description: "Cable, Satellite, and Other Pay Television and Radio Services"},
+	{Code: "4900", Description: "Utilities - Electric, Gas, Water, and Sanitary"},
+	{Code: "5013", Description: "Motor Vehicle Supplies and New Parts"},
+	{Code: "5021", Description: "Office and Commercial Furniture"},
+	{Code: "5039", Description: "Construction Materials"},
+	{Code: "5044", Description: "Photographic, Photocopy, Microfilm Equipment, and Supplies"},
+	{Code: "5045", Description: "Computers, Computer Peripheral Equipment, and Software"},
+	{Code: "5046", Description: "Commercial Equipment"},
+	{Code: "5047", Description: "Medical, Dental, Ophthalmic, and Hospital Equipment and Supplies"},
+	{Code: "5051", Description: "Metal Service Centers and Offices"},
+	{Code: "5065", Description: "Electrical Parts and Equipment"},
+	{Code: "5072", Description: "Hardware Equipment and Supplies"},
+	{Code: "5074", Description: "Plumbing and Heating Equipment and Supplies"},
+	{Code: "5085", Description: "Industrial Supplies"},
+	{Code: "5094", Description: "Precious Stones and Metals, Watches, and Jewelry"},
+	{Code: "5099", Description: "Durable Goods"},
+	{Code: "5111", Description: "Stationery, Office Supplies, Printing, and Writing Paper"},
+	{Code: "5122", Description: "Drugs, Drug Proprietaries, and Druggist Sundries"},
+	{Code: "5131", Description: "Piece Goods, Notions, and Other Dry Goods"},
+	{Code: "5137", Description: "Men's, Women's, and Children's Uniforms and Commercial Clothing"},
+	{Code: "5139", Description: "Commercial Footwear"},
+	{Code: "5169", Description: "Chemicals and Allied Products"},
+	{Code: "5172", Description: "Petroleum and Petroleum Products"},
+	{Code: "5192", Description: "Books, Periodicals, and Newspapers"},
+	{Code: "5193", Description: "Florists' Supplies, Nursery Stock, and Flowers"},
+	{Code: "5198", Description: "Paints, Varnishes, and Supplies"},
+	{Code: "5199", Description: "Nondurable Goods"},
+	{Code: "5200", Description: "Home Supply Warehouse Stores"},
+	{Code: "5211", Description: "Lumber and Building Materials Stores"},
+	{Code: "5231", Description: "Glass, Paint, and Wallpaper Stores"},
+	{Code: "5251", Description: "Hardware Stores"},
+	{Code: "5261", Description: "Lawn and Garden Supply Stores, Including Nurseries"},
+	{Code: "5262", Description: "Marketplaces"},
+	{Code: "5271", Description: "Mobile Home Dealers"},
+	{Code: "5300", Description: "Wholesale Clubs"},
+	{Code: "5309", Description: "Duty Free Stores"},
+	{Code: "5310", Description: "Discount Stores"},
+	{Code: "5311", Description: "Department Stores"},
+	{Code: "5331", Description: "Variety Stores"},
+	{Code: "5399", Description: "Miscellaneous General Merchandise"},
+	{Code: "5411", Description: "Grocery Stores, Supermarkets"},
+	{Code: "5422", Description: "Freezer and Locker Meat Provisioners"},
+	{Code: "5441", Description: "Candy, Nut, and Confectionery Stores"},
+	{Code: "5451", Description: "Dairy Products Stores"},
+	{Code: "5462", Description: "Bakeries"},
+	{Code: "5499", Description: "Miscellaneous Food Stores - Convenience Stores and Specialty Markets"},
+	{Code: "5511", Description: "Car and Truck Dealers (New and Used)"},
+	{Code: "5521", Description: "Car and Truck Dealers (Used Only)"},
+	{Code: "5531", Description: "Auto and Home Supply Stores"},
+	{Code: "5532", Description: "Automotive Tire Stores"},
+	{Code: "5533", Description: "Automotive Parts and Accessories Stores"},
+	{Code: "5541", Description: "Service Stations"},
+	{Code: "5542", Description: "Automated Fuel Dispensers"},
+	{Code: "5551", Description: "Boat Dealers"},
+	{Code: "5552", Description: "Electric Vehicle Charging"},
+	{Code: "5561", Description: "Camper, Recreational, and Utility Trailer Dealers"},
+	{Code: "5571", Description: "Motorcycle Shops and Dealers"},
+	{Code: "5592", Description: "Motor Home Dealers"},
+	{Code: "5598", Description: "Snowmobile Dealers"},
+	{Code: "5599", Description: "Miscellaneous Automotive, Aircraft, and Farm Equipment Dealers"},
+	{Code: "5611", Description: "Men's and Boys' Clothing and Accessories Stores"},
+	{Code: "5621", Description: "Women's Ready-to-Wear Stores"},
+	{Code: "5631", Description: "Women's Accessory and Specialty Shops"},
+	{Code: "5641", Description: "Children's and Infants' Wear Stores"},
+	{Code: "5651", Description: "Family Clothing Stores"},
+	{Code: "5655", Description: "Sports and Riding Apparel Stores"},
+	{Code: "5661", Description: "Shoe Stores"},
+	{Code: "5681", Description: "Furriers and Fur Shops"},
+	{Code: "5691", Description: "Men's and Women's Clothing Stores"},
+	{Code: "5697", Description: "Tailors, Seamstresses, Mending, and Alterations"},
+	{Code: "5698", Description: "Wig and Toupee Stores"},
+	{Code: "5699", Description: "Miscellaneous Apparel and Accessory Shops"},
+	{Code: "5712", Description: "Furniture, Home Furnishings, and Equipment Stores, Except Appliances"},
+	{Code: "5713", Description: "Floor Covering Stores"},
+	{Code: "5714", Description: "Drapery, Window Covering, and Upholstery Stores"},
+	{Code: "5718", Description: "Fireplaces, Fireplace Screens, and Accessories Stores"},
+	{Code: "5719", Description: "Miscellaneous Home Furnishing Specialty Stores"},
+	{Code: "5722", Description: "Household Appliance Stores"},
+	{Code: "5732", Description: "Electronics Stores"},
+	{Code: "5733", Description: "Music Stores - Musical Instruments, Pianos, and Sheet Music"},
+	{Code: "5734", Description: "Computer Software Stores"},
+	{Code: "5735", Description: "Record Stores"},
+	{Code: "5811", Description: "Caterers"},
+	{Code: "5812", Description: "Eating Places, Restaurants"},
+	{Code: "5813", Description: "Drinking Places (Alcoholic Beverages) - Bars, Taverns, Nightclubs"},
+	{Code: "5814", Description: "Fast Food Restaurants"},
+	{Code: "5815", Description: "Digital Goods - Media, Books, Movies, Music"},
+	{Code: "5816", Description: "Digital Goods - Games"},
+	{Code: "5817", Description: "Digital Goods - Applications (Excludes Games)"},
+	{Code: "5818", Description: "Digital Goods - Large Digital Goods Merchant"},
+	{Code: "5912", Description: "Drug Stores and Pharmacies"},
+	{Code: "5921", Description: "Package Stores - Beer, Wine, and Liquor"},
+	{Code: "5931", Description: "Used Merchandise and Secondhand Stores"},
+	{Code: "5932", Description: "Antique Shops - Sales, Repairs, and Restoration Services"},
+	{Code: "5933", Description: "Pawn Shops"},
+	{Code: "5935", Description: "Wrecking and Salvage Yards"},
+	{Code: "5937", Description: "Antique Reproductions"},
+	{Code: "5940", Description: "Bicycle Shops - Sales and Service"},
+	{Code: "5941", Description: "Sporting Goods Stores"},
+	{Code: "5942", Description: "Book Stores"},
+	{Code: "5943", Description: "Stationery, Office, and School Supply Stores"},
+	{Code: "5944", Description: "Jewelry, Watch, Clock, and Silverware Stores"},
+	{Code: "5945", Description: "Hobby, Toy, and Game Shops"},
+	{Code: "5946", Description: "Camera and Photographic Supply Stores"},
+	{Code: "5947", Description: "Gift, Card, Novelty, and Souvenir Shops"},
+	{Code: "5948", Description: "Luggage and Leather Goods Stores"},
+	{Code: "5949", Description: "Sewing, Needlework, Fabric, and Piece Goods Stores"},
+	{Code: "5950", Description: "Glassware and Crystal Stores"},
+	{Code: "5960", Description: "Direct Marketing - Insurance Services"},
+	{Code: "5961", Description: "Mail Order Houses"},
+	{Code: "5962", Description: "Direct Marketing - Travel-Related Arrangement Services"},
+	{Code: "5963", Description: "Door-to-Door Sales"},
+	{Code: "5964", Description: "Direct Marketing - Catalog Merchants"},
+	{Code: "5965", Description: "Direct Marketing - Combination Catalog and Retail Merchants"},
+	{Code: "5966", Description: "Direct Marketing - Outbound Telemarketing Merchants"},
+	{Code: "5967", Description: "Direct Marketing - Inbound Teleservices Merchants"},
+	{Code: "5968", Description: "Direct Marketing - Continuity/Subscription Merchants"},
+	{Code: "5969", Description: "Direct Marketing - Other Direct Marketers"},
+	{Code: "5970", Description: "Artist's Supply and Craft Shops"},
+	{Code: "5971", Description: "Art Dealers and Galleries"},
+	{Code: "5972", Description: "Stamp and Coin Stores"},
+	{Code: "5973", Description: "Religious Goods Stores"},
+	{Code: "5975", Description: "Hearing Aids - Sales, Service, and Supplies"},
+	{Code: "5976", Description: "Orthopedic Goods and Prosthetic Devices"},
+	{Code: "5977", Description: "Cosmetic Stores"},
+	{Code: "5978", Description: "Typewriter Stores - Sales, Rentals, and Service"},
+	{Code: "5983", Description: "Fuel Dealers - Fuel Oil, Wood, Coal, and Liquefied Petroleum"},
+	{Code: "5992", Description: "Florists"},
+	{Code: "5993", Description: "Cigar Stores and Stands"},
+	{Code: "5994", Description: "News Dealers and Newsstands"},
+	{Code: "5995", Description: "Pet Shops, Pet Food, and Supplies"},
+	{Code: "5996", Description: "Swimming Pools - Sales, Supplies, and Services"},
+	{Code: "5997", Description: "Electric Razor Stores - Sales and Service"},
+	{Code: "5998", Description: "Tent and Awning Shops"},
+	{Code: "5999", Description: "Miscellaneous and Specialty Retail Stores"},
+	{Code: "6010", Description: "Financial Institutions - Manual Cash Disbursements"},
+	{Code: "6011", Description: "Financial Institutions - Automated Cash Disbursements"},
+	{Code: "6012", Description: "Financial Institutions - Merchandise, Services, and Debt Repayment"},
+	{Code: "6050", Description: "Quasi Cash - Financial Institutions"},
+	{Code: "6051", Description: "Non-Financial Institutions - Foreign Currency, Money Orders, and Quasi Cash"},
+	{Code: "6211", Description: "Security Brokers and Dealers"},
+	{Code: "6300", Description: "Insurance Sales, Underwriting, and Premiums"},
+	{Code: "6381", Description: "Insurance Premiums"},
+	{Code: "6399", Description: "Insurance"},
+	{Code: "6513", Description: "Real Estate Agents and Managers - Rentals"},
+	{Code: "6529", Description: "Remote Stored Value Load - Financial Institutions"},
+	{Code: "6530", Description: "Remote Stored Value Load - Merchants"},
+	{Code: "6532", Description: "Payment Transaction - Financial Institutions"},
+	{Code: "6533", Description: "Payment Transaction - Merchants"},
+	{Code: "6534", Description: "Money Transfer - Financial Institutions"},
+	{Code: "6535", Description: "Value Purchase - Financial Institutions"},
+	{Code: "6536", Description: "MoneySend Intracountry"},
+	{Code: "6537", Description: "MoneySend Intercountry"},
+	{Code: "6538", Description: "MoneySend Funding"},
+	{Code: "6540", Description: "Non-Financial Institutions - Stored Value Card Purchase and Load"},
+	{Code: "6611", Description: "Overpayments"},
+	{Code: "6760", Description: "Savings Bonds"},
+	{Code: "7011", Description: "Lodging - Hotels, Motels, and Resorts"},
+	{Code: "7012", Description: "Timeshares"},
+	{Code: "7032", Description: "Sporting and Recreational Camps"},
+	{Code: "7033", Description: "Trailer Parks and Campgrounds"},
+	{Code: "7210", Description: "Laundry, Cleaning, and Garment Services"},
+	{Code: "7211", Description: "Laundries - Family and Commercial"},
+	{Code: "7216", Description: "Dry Cleaners"},
+	{Code: "7217", Description: "Carpet and Upholstery Cleaning"},
+	{Code: "7221", Description: "Photographic Studios"},
+	{Code: "7230", Description: "Beauty and Barber Shops"},
+	{Code: "7251", Description: "Shoe Repair Shops, Shoe Shine Parlors, and Hat Cleaning Shops"},
+	{Code: "7261", Description: "Funeral Services and Crematories"},
+	{Code: "7273", Description: "Dating Services"},
+	{Code: "7276", Description: "Tax Preparation Services"},
+	{Code: "7277", Description: "Counseling Services - Debt, Marriage, and Personal"},
+	{Code: "7278", Description: "Buying and Shopping Services and Clubs"},
+	{Code: "7295", Description: "Babysitting Services"},
+	{Code: "7296", Description: "Clothing Rental - Costumes, Uniforms, and Formal Wear"},
+	{Code: "7297", Description: "Massage Parlors"},
+	{Code: "7298", Description: "Health and Beauty Spas"},
+	{Code: "7299", Description: "Miscellaneous Personal Services"},
+	{Code: "7311", Description: "Advertising Services"},
+	{Code: "7321", Description: "Consumer Credit Reporting Agencies"},
+	{Code: "7322", Description: "Debt Collection Agencies"},
+	{Code: "7333", Description: "Commercial Photography, Art, and Graphics"},
+	{Code: "7338", Description: "Quick Copy, Reproduction, and Blueprinting Services"},
+	{Code: "7339", Description: "Stenographic and Secretarial Support Services"},
+	{Code: "7342", Description: "Exterminating and Disinfecting Services"},
+	{Code: "7349", Description: "Cleaning, Maintenance, and Janitorial Services"},
+	{Code: "7361", Description: "Employment Agencies and Temporary Help Services"},
+	{Code: "7372", Description: "Computer Programming, Data Processing, and Integrated Systems Design Services"},
+	{Code: "7375", Description: "Information Retrieval Services"},
+	{Code: "7379", Description: "Computer Maintenance, Repair, and Services"},
+	{Code: "7392", Description: "Management, Consulting, and Public Relations Services"},
+	{Code: "7393", Description: "Detective Agencies, Protective Agencies, and Security Services"},
+	{Code: "7394", Description: "Equipment, Tool, Furniture, and Appliance Rental and Leasing"},
+	{Code: "7395", Description: "Photofinishing Laboratories and Photo Developing"},
+	{Code: "7399", Description: "Business Services"},
+	{Code: "7511", Description: "Truck Stops"},
+	{Code: "7512", Description: "Automobile Rental Agencies"},
+	{Code: "7513", Description: "Truck and Utility Trailer Rentals"},
+	{Code: "7519", Description: "Motor Home and Recreational Vehicle Rentals"},
+	{Code: "7523", Description: "Parking Lots and Garages"},
+	{Code: "7524", Description: "Express Payment Service Merchants - Parking Lots and Garages"},
+	{Code: "7531", Description: "Automotive Body Repair Shops"},
+	{Code: "7534", Description: "Tire Retreading and Repair Shops"},
+	{Code: "7535", Description: "Automotive Paint Shops"},
+	{Code: "7538", Description: "Automotive Service Shops (Non-Dealer)"},
+	{Code: "7542", Description: "Car Washes"},
+	{Code: "7549", Description: "Towing Services"},
+	{Code: "7622", Description: "Electronics Repair Shops"},
+	{Code: "7623", Description: "Air Conditioning and Refrigeration Repair Shops"},
+	{Code: "7629", Description: "Electrical and Small Appliance Repair Shops"},
+	{Code: "7631", Description: "Watch, Clock, and Jewelry Repair Shops"},
+	{Code: "7641", Description: "Furniture Reupholstery, Repair, and Refinishing"},
+	{Code: "7692", Description: "Welding Services"},
+	{Code: "7699", Description: "Miscellaneous Repair Shops and Related Services"},
+	{Code: "7800", Description: "Government-Owned Lotteries"},
+	{Code: "7801", Description: "Government-Licensed Online Casinos"},
+	{Code: "7802", Description: "Government-Licensed Horse/Dog Racing"},
+	{Code: "7829", Description: "Motion Picture and Video Tape Production and Distribution"},
+	{Code: "7832", Description: "Motion Picture Theaters"},
+	{Code: "7841", Description: "Video Tape Rental Stores"},
+	{Code: "7911", Description: "Dance Halls, Studios, and Schools"},
+	{Code: "7922", Description: "Theatrical Producers and Ticket Agencies"},
+	{Code: "7929", Description: "Bands, Orchestras, and Miscellaneous Entertainers"},
+	{Code: "7932", Description: "Billiard and Pool Establishments"},
+	{Code: "7933", Description: "Bowling Alleys"},
+	{Code: "7941", Description: "Commercial Sports, Professional Sports Clubs, Athletic Fields, and Sports Promoters"},
+	{Code: "7991", Description: "Tourist Attractions and Exhibits"},
+	{Code: "7992", Description: "Public Golf Courses"},
+	{Code: "7993", Description: "Video Amusement Game Supplies"},
+	{Code: "7994", Description: "Video Game Arcades and Establishments"},
+	{Code: "7995", Description: "Betting, Including Lottery Tickets, Casino Gaming Chips, and Off-Track Betting"},
+	{Code: "7996", Description: "Amusement Parks, Circuses, Carnivals, and Fortune Tellers"},
+	{Code: "7997", Description: "Membership Clubs, Country Clubs, and Private Golf Courses"},
+	{Code: "7998", Description: "Aquariums, Seaquariums, and Dolphinariums"},
+	{Code: "7999", Description: "Recreation Services"},
+	{Code: "8011", Description: "Doctors"},
+	{Code: "8021", Description: "Dentists and Orthodontists"},
+	{Code: "8031", Description: "Osteopaths"},
+	{Code: "8041", Description: "Chiropractors"},
+	{Code: "8042", Description: "Optometrists and Ophthalmologists"},
+	{Code: "8043", Description: "Opticians, Optical Goods, and Eyeglasses"},
+	{Code: "8049", Description: "Podiatrists and Chiropodists"},
+	{Code: "8050", Description: "Nursing and Personal Care Facilities"},
+	{Code: "8062", Description: "Hospitals"},
+	{Code: "8071", Description: "Medical and Dental Laboratories"},
+	{Code: "8099", Description: "Medical Services and Health Practitioners"},
+	{Code: "8111", Description: "Legal Services and Attorneys"},
+	{Code: "8211", Description: "Elementary and Secondary Schools"},
+	{Code: "8220", Description: "Colleges, Universities, Professional Schools, and Junior Colleges"},
+	{Code: "8241", Description: "Correspondence Schools"},
+	{Code: "8244", Description: "Business and Secretarial Schools"},
+	{Code: "8249", Description: "Vocational and Trade Schools"},
+	{Code: "8299", Description: "Schools and Educational Services"},
+	{Code: "8351", Description: "Child Care Services"},
+	{Code: "8398", Description: "Charitable and Social Service Organizations"},
+	{Code: "8641", Description: "Civic, Social, and Fraternal Associations"},
+	{Code: "8651", Description: "Political Organizations"},
+	{Code: "8661", Description: "Religious Organizations"},
+	{Code: "8675", Description: "Automobile Associations"},
+	{Code: "8699", Description: "Membership Organizations"},
+	{Code: "8734", Description: "Testing Laboratories (Non-Medical)"},
+	{Code: "8911", Description: "Architectural, Engineering, and Surveying Services"},
+	{Code: "8931", Description: "Accounting, Auditing, and Bookkeeping Services"},
+	{Code: "8999", Description: "Professional Services"},
+	{Code: "9211", Description: "Court Costs, Including Alimony and Child Support"},
+	{Code: "9222", Description: "Fines"},
+	{Code: "9223", Description: "Bail and Bond Payments"},
+	{Code: "9311", Description: "Tax Payments"},
+	{Code: "9399", Description: "Government Services"},
+	{Code: "9402", Description: "Postal Services - Government Only"},
+	{Code: "9405", Description: "Intra-Government Purchases - Government Only"},
+	{Code: "9702", Description: "Emergency Services"},
+	{Code: "9950", Description: "Intra-Company Purchases"},
+})
+
+func withGroups(categories []MerchantCategory) []MerchantCategory {
+	for i := range categories {
+		if g, ok := lookupMCCGroup(categories[i].Code); ok {
+			categories[i].Group = g.name
+		}
+	}
+	return categories
+}
+
+func lookupMCCGroup(code string) (mccGroup, bool) {
+	for _, g := range mccGroups {
+		if g.Contains(code) {
+			return g, true
+		}
+	}
+	return mccGroup{}, false
+}
+
+var merchantCategoryIndex = func() map[string]MerchantCategory {
+	m := make(map[string]MerchantCategory, len(merchantCategories))
+	for _, c := range merchantCategories {
+		m[c.Code] = c
+	}
+	return m
+}()
+
+// MerchantCategories returns the ISO 18245 table.
+func MerchantCategories() []MerchantCategory {
+	c := make([]MerchantCategory, len(merchantCategories))
+	copy(c, merchantCategories)
+	return c
+}
+
+// LookupMerchantCategory returns the merchant category of the Merchant
+// Category Code code. A brand code, e.g. "3015" of an airline, is described
+// by its group.
+func LookupMerchantCategory(code string) (MerchantCategory, bool) {
+	if c, ok := merchantCategoryIndex[code]; ok {
+		return c, true
+	}
+	if g, ok := lookupMCCGroup(code); ok && g.brand {
+		return MerchantCategory{Code: code, Description: g.name, Group: g.name}, true
+	}
+	return MerchantCategory{}, false
+}
+
+// MerchantCategory returns the merchant category of the Merchant Category
+// Code.
+func (c *EMVQR) MerchantCategory() (MerchantCategory, bool) {
+	return LookupMerchantCategory(c.MerchantCategoryCode.Value)
+}
@@ -0,0 +1,89 @@
+package mpm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupMerchantCategory(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   MerchantCategory
+		wantOK bool
+	}{
+		{code: "5411", want: MerchantCategory{Code: "5411", Description: "Grocery Stores, Supermarkets", Group: "Retail Outlet Services"}, wantOK: true},
+		{code: "4111", want: MerchantCategory{Code: "4111", Description: "Local and Suburban Commuter Passenger Transportation, Including Ferries", Group: "Transportation Services"}, wantOK: true},
+		{code: "3015", want: MerchantCategory{Code: "3015", Description: "Airlines", Group: "Airlines"}, wantOK: true},
+		{code: "0000", wantOK: false},
+		{code: "5410", wantOK: false},
+		{code: "54111", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := LookupMerchantCategory(tt.code)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupMerchantCategory() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEMVQR_MerchantCategory(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.MerchantCategory(); !ok || got.Group != "Transportation Services" {
+		t.Errorf("EMVQR.MerchantCategory() = %v, %v, want Transportation Services", got, ok)
+	}
+}
+
+func TestEMVQR_ValidateAllWithOptions_MerchantCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		mcc  string
+		opts ValidationOptions
+		want []Violation
+	}{
+		{
+			name: "not checked by default",
+			mcc:  "0000",
+		},
+		{
+			name: "known",
+			mcc:  "5411",
+			opts: ValidationOptions{UnknownMCC: true},
+		},
+		{
+			name: "unknown",
+			mcc:  "0000",
+			opts: ValidationOptions{UnknownMCC: true},
+			want: []Violation{
+				{Path: "52", Field: "MerchantCategoryCode", Rule: RuleMerchantCategory, Severity: SeverityError, Value: "0000", Message: "MerchantCategoryCode should be an ISO 18245 code, MerchantCategoryCode: 0000"},
+			},
+		},
+		{
+			name: "allowed range",
+			mcc:  "0000",
+			opts: ValidationOptions{UnknownMCC: true, AllowedMCCRanges: []MCCRange{{Start: "0000", End: "0099"}}},
+		},
+		{
+			name: "blocked",
+			mcc:  "7995",
+			opts: ValidationOptions{BlockedMCCs: []string{"7801", "7995"}},
+			want: []Violation{
+				{Path: "52", Field: "MerchantCategoryCode", Rule: RuleMerchantCategory, Severity: SeverityError, Value: "7995", Message: "MerchantCategoryCode is blocked, MerchantCategoryCode: 7995"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			c.SetMerchantCategoryCode(tt.mcc)
+			got := c.ValidateAllWithOptions(tt.opts)
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAllWithOptions() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
@@ -5,15 +5,45 @@ func Encode(emvqr *EMVQR) (string, error) {
 	if err := emvqr.Validate(); err != nil {
 		return "", err
 	}
-	return emvqr.GeneratePayload(), nil
+	return emvqr.GeneratePayload()
+}
+
+// DecodeOptions ...
+type DecodeOptions struct {
+	// SkipCRCCheck disables the CRC (ID "63") checks, so that corrupted or
+	// tampered payloads can still be inspected.
+	SkipCRCCheck bool
+	// Lossless keeps every data object of the payload, including those the
+	// library does not model, so that GeneratePayload re-emits them in their
+	// original position and decode followed by encode reproduces the payload.
+	Lossless bool
+	// Lenient repairs common defects, such as line breaks or a stale CRC,
+	// before decoding. Use DecodeLenient to see the repairs made.
+	Lenient bool
+	// Duplicates is the policy for an ID repeated within a template, at any
+	// nesting level. By default a repeated ID is rejected, Lossless
+	// included.
+	Duplicates DuplicatePolicy
 }
 
 // Decode ...
 func Decode(payload string) (*EMVQR, error) {
-	emvqr, err := ParseEMVQR(payload)
+	return DecodeWithOptions(payload, DecodeOptions{})
+}
+
+// DecodeWithOptions ...
+func DecodeWithOptions(payload string, opts DecodeOptions) (*EMVQR, error) {
+	if opts.Lenient {
+		emvqr, _, err := DecodeLenient(payload, opts)
+		return emvqr, err
+	}
+	emvqr, err := ParseEMVQRWithOptions(payload, opts)
 	if err != nil {
 		return nil, err
 	}
+	if !opts.SkipCRCCheck && emvqr.CRC.Value == "" {
+		return nil, ErrCRCMissing
+	}
 	if err := emvqr.Validate(); err != nil {
 		return nil, err
 	}
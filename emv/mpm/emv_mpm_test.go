@@ -342,6 +342,7 @@ func TestDecode(t *testing.T) {
 					Length: "04",
 					Value:  "A13A",
 				},
+				insertionOrder: []ID{"29", "31", "91"},
 			},
 			wantErr: false,
 		},
@@ -367,3 +368,83 @@ func TestDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeWithOptions(t *testing.T) {
+	const valid = "00020101021126160012D123456789015204541153033925802JP5906DONGRI6005TOKYO"
+	type args struct {
+		payload string
+		opts    DecodeOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantCRC string
+		wantErr error
+	}{
+		{
+			name: "ok",
+			args: args{
+				payload: valid + formatCrc(valid),
+			},
+			wantCRC: formatCrc(valid)[4:],
+		},
+		{
+			name: "missing crc",
+			args: args{
+				payload: valid,
+			},
+			wantErr: ErrCRCMissing,
+		},
+		{
+			name: "crc not last",
+			args: args{
+				payload: valid[:6] + formatCrc(valid) + valid[6:],
+			},
+			wantErr: ErrCRCNotLast,
+		},
+		{
+			name: "crc mismatch",
+			args: args{
+				payload: valid + "6304FFFF",
+			},
+			wantErr: &CRCError{
+				Expected: formatCrc(valid)[4:],
+				Actual:   "FFFF",
+			},
+		},
+		{
+			name: "skip crc check",
+			args: args{
+				payload: valid + "6304FFFF",
+				opts: DecodeOptions{
+					SkipCRCCheck: true,
+				},
+			},
+			wantCRC: "FFFF",
+		},
+		{
+			name: "skip crc check without crc",
+			args: args{
+				payload: valid,
+				opts: DecodeOptions{
+					SkipCRCCheck: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeWithOptions(tt.args.payload, tt.args.opts)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("DecodeWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.CRC.Value != tt.wantCRC {
+				t.Errorf("DecodeWithOptions() CRC = %v, want %v", got.CRC.Value, tt.wantCRC)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package mpm
+
+import (
+	"sort"
+)
+
+// Order is the policy used to arrange repeated data objects
+// (Merchant Account Information, Unreserved Templates and the
+// ID-keyed lists inside nested templates) when encoding.
+type Order int
+
+// const ...
+const (
+	// OrderAscending emits data objects in ascending ID order.
+	OrderAscending Order = iota
+	// OrderInsertion emits data objects in the order they were added.
+	// ParseEMVQR adds them in the order they appear in the payload, so a
+	// decoded EMVQR re-encodes in its original arrival order.
+	OrderInsertion
+)
+
+// SetOrder sets the ordering policy used by GeneratePayload, RawData,
+// BinaryData and JSON. The default is OrderAscending.
+func (c *EMVQR) SetOrder(o Order) {
+	c.order = o
+}
+
+func (c *EMVQR) addInsertionOrder(id ID) {
+	for _, v := range c.insertionOrder {
+		if v == id {
+			return
+		}
+	}
+	c.insertionOrder = append(c.insertionOrder, id)
+}
+
+func (c *EMVQR) merchantAccountInformationIDs() []ID {
+	ids := make([]ID, 0, len(c.MerchantAccountInformation))
+	for id := range c.MerchantAccountInformation {
+		ids = append(ids, id)
+	}
+	return c.orderIDs(ids)
+}
+
+func (c *EMVQR) unreservedTemplateIDs() []ID {
+	ids := make([]ID, 0, len(c.UnreservedTemplates))
+	for id := range c.UnreservedTemplates {
+		ids = append(ids, id)
+	}
+	return c.orderIDs(ids)
+}
+
+// orderIDs sorts ids ascending and, for OrderInsertion, moves the IDs that
+// were added through the setters to the front in the order they were added.
+func (c *EMVQR) orderIDs(ids []ID) []ID {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	if c.order != OrderInsertion {
+		return ids
+	}
+	rank := make(map[ID]int, len(c.insertionOrder))
+	for i, id := range c.insertionOrder {
+		rank[id] = i
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		ri, iok := rank[ids[i]]
+		rj, jok := rank[ids[j]]
+		if iok && jok {
+			return ri < rj
+		}
+		return iok && !jok
+	})
+	return ids
+}
+
+// ordered returns a copy of c whose nested templates are arranged
+// according to the ordering policy.
+func (c *EMVQR) ordered() *EMVQR {
+	o := *c
+	o.MerchantAccountPrimitives = orderTLVs(c.MerchantAccountPrimitives, c.order)
+	if c.MerchantAccountInformation != nil {
+		o.MerchantAccountInformation = make(map[ID]MerchantAccountInformationTLV, len(c.MerchantAccountInformation))
+		for id, m := range c.MerchantAccountInformation {
+			m.Value = m.Value.ordered(c.order)
+			o.MerchantAccountInformation[id] = m
+		}
+	}
+	o.AdditionalDataFieldTemplate = c.AdditionalDataFieldTemplate.ordered(c.order)
+	o.MerchantInformationLanguageTemplate = c.MerchantInformationLanguageTemplate.ordered(c.order)
+	o.RFUforEMVCo = orderTLVs(c.RFUforEMVCo, c.order)
+	if c.UnreservedTemplates != nil {
+		o.UnreservedTemplates = make(map[ID]UnreservedTemplateTLV, len(c.UnreservedTemplates))
+		for id, u := range c.UnreservedTemplates {
+			u.Value = u.Value.ordered(c.order)
+			o.UnreservedTemplates[id] = u
+		}
+	}
+	return &o
+}
+
+func (s *MerchantAccountInformation) ordered(order Order) *MerchantAccountInformation {
+	if s == nil {
+		return nil
+	}
+	t := *s
+	t.PaymentNetworkSpecific = orderTLVs(s.PaymentNetworkSpecific, order)
+	return &t
+}
+
+func (s *AdditionalDataFieldTemplate) ordered(order Order) *AdditionalDataFieldTemplate {
+	if s == nil {
+		return nil
+	}
+	t := *s
+	t.RFUforEMVCo = orderTLVs(s.RFUforEMVCo, order)
+	if s.PaymentSystemSpecific != nil {
+		t.PaymentSystemSpecific = make([]PaymentSystemSpecificTemplateTLV, len(s.PaymentSystemSpecific))
+		for i, p := range s.PaymentSystemSpecific {
+			p.Value = p.Value.ordered(order)
+			t.PaymentSystemSpecific[i] = p
+		}
+		if order == OrderAscending {
+			sort.SliceStable(t.PaymentSystemSpecific, func(i, j int) bool {
+				return t.PaymentSystemSpecific[i].Tag < t.PaymentSystemSpecific[j].Tag
+			})
+		}
+	}
+	return &t
+}
+
+func (s *PaymentSystemSpecificTemplate) ordered(order Order) *PaymentSystemSpecificTemplate {
+	if s == nil {
+		return nil
+	}
+	t := *s
+	t.PaymentSystemSpecific = orderTLVs(s.PaymentSystemSpecific, order)
+	return &t
+}
+
+func (s *MerchantInformationLanguageTemplate) ordered(order Order) *MerchantInformationLanguageTemplate {
+	if s == nil {
+		return nil
+	}
+	t := *s
+	t.RFUforEMVCo = orderTLVs(s.RFUforEMVCo, order)
+	return &t
+}
+
+func (s *UnreservedTemplate) ordered(order Order) *UnreservedTemplate {
+	if s == nil {
+		return nil
+	}
+	t := *s
+	t.ContextSpecificData = orderTLVs(s.ContextSpecificData, order)
+	return &t
+}
+
+func orderTLVs(tlvs []TLV, order Order) []TLV {
+	if order != OrderAscending || tlvs == nil {
+		return tlvs
+	}
+	sorted := make([]TLV, len(tlvs))
+	copy(sorted, tlvs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Tag < sorted[j].Tag
+	})
+	return sorted
+}
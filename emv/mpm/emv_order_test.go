@@ -0,0 +1,87 @@
+package mpm
+
+import (
+	"testing"
+)
+
+func TestEMVQR_GeneratePayload_Order(t *testing.T) {
+	build := func(order Order) *EMVQR {
+		c := new(EMVQR)
+		c.SetOrder(order)
+		c.SetPayloadFormatIndicator("01")
+		m31 := new(MerchantAccountInformation)
+		m31.SetGloballyUniqueIdentifier("M123456")
+		m31.AddPaymentNetworkSpecific("04", "MASTER")
+		m31.AddPaymentNetworkSpecific("01", "X")
+		c.AddMerchantAccountInformation(ID("31"), m31)
+		m29 := new(MerchantAccountInformation)
+		m29.SetGloballyUniqueIdentifier("D123456")
+		c.AddMerchantAccountInformation(ID("29"), m29)
+		u := new(UnreservedTemplate)
+		u.SetGloballyUniqueIdentifier("abcd")
+		c.AddUnreservedTemplates(ID("91"), u)
+		c.AddUnreservedTemplates(ID("80"), u)
+		c.AddRFUforEMVCo(ID("66"), "ab")
+		c.AddRFUforEMVCo(ID("65"), "cd")
+		return c
+	}
+	tests := []struct {
+		name  string
+		order Order
+		want  string
+	}{
+		{
+			name:  "ascending",
+			order: OrderAscending,
+			want:  "000201" + "29110007D123456" + "31260007M1234560101X0406MASTER" + "6502cd" + "6602ab" + "80080004abcd" + "91080004abcd",
+		},
+		{
+			name:  "insertion",
+			order: OrderInsertion,
+			want:  "000201" + "31260007M1234560406MASTER0101X" + "29110007D123456" + "6602ab" + "6502cd" + "91080004abcd" + "80080004abcd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := build(tt.order)
+			want := tt.want + formatCrc(tt.want)
+			for i := 0; i < 20; i++ {
+				if got, err := c.GeneratePayload(); err != nil || got != want {
+					t.Fatalf("EMVQR.GeneratePayload() = %v, %v, want %v", got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEMVQR_GeneratePayload_DecodedOrder(t *testing.T) {
+	payload := "00020131080004abcd29080004efgh"
+	c, err := ParseEMVQR(payload)
+	if err != nil {
+		t.Fatalf("ParseEMVQR() error = %v", err)
+	}
+	c.SetOrder(OrderInsertion)
+	if got, err := c.GeneratePayload(); err != nil || got != payload+formatCrc(payload) {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", got, err, payload+formatCrc(payload))
+	}
+	c.SetOrder(OrderAscending)
+	ascending := "00020129080004efgh31080004abcd"
+	if got, err := c.GeneratePayload(); err != nil || got != ascending+formatCrc(ascending) {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", got, err, ascending+formatCrc(ascending))
+	}
+}
+
+func TestEMVQR_RawData_Order(t *testing.T) {
+	c := new(EMVQR)
+	for _, id := range []ID{"35", "27", "30"} {
+		m := new(MerchantAccountInformation)
+		m.SetGloballyUniqueIdentifier("G" + id.String())
+		c.AddMerchantAccountInformation(id, m)
+	}
+	want := "27 07\n  00 03 G27\n30 07\n  00 03 G30\n35 07\n  00 03 G35\n"
+	for i := 0; i < 20; i++ {
+		if got := c.RawData(); got != want {
+			t.Fatalf("EMVQR.RawData() = %q, want %q", got, want)
+		}
+	}
+}
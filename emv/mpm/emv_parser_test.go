@@ -157,7 +157,7 @@ func TestParser_Next(t *testing.T) {
 				current: 0,
 				max:     12,
 				source:  []rune("00ab0101cd11"),
-				err:     syntaxError(fnValueLength, "ab"),
+				err:     syntaxError(fnValueLength, "ab").at(0, "00"),
 			},
 		},
 		{
@@ -189,7 +189,7 @@ func TestParser_Next(t *testing.T) {
 				current: 0,
 				max:     0,
 				source:  []rune(""),
-				err:     outOfRangeError(fnValueLength, 0, 0, IDWordCount, IDWordCount+ValueLengthWordCount),
+				err:     outOfRangeError(fnValueLength, 0, 0, IDWordCount, IDWordCount+ValueLengthWordCount).at(0, ""),
 			},
 		},
 		{
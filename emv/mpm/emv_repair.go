@@ -0,0 +1,113 @@
+package mpm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RepairRule is the kind of defect fixed by a lenient decode.
+type RepairRule string
+
+// const ...
+const (
+	RepairLineBreaks  RepairRule = "line-breaks"  // CR and LF characters removed
+	RepairWhitespace  RepairRule = "whitespace"   // leading and trailing whitespace removed
+	RepairLength      RepairRule = "length"       // off-by-one length of the last data object corrected
+	RepairCRCCase     RepairRule = "crc-case"     // lower case CRC upper cased
+	RepairCRCMissing  RepairRule = "crc-missing"  // CRC appended
+	RepairCRCMismatch RepairRule = "crc-mismatch" // stale CRC recalculated
+)
+
+// Repair is a defect fixed by a lenient decode.
+type Repair struct {
+	Rule    RepairRule `json:"rule"`
+	Tag     string     `json:"tag,omitempty"` // tag path, e.g. "60"
+	Message string     `json:"message"`
+}
+
+// Repaired is the outcome of DecodeLenient.
+type Repaired struct {
+	// Payload is the payload with every repair applied and a valid CRC.
+	Payload string
+	Repairs []Repair
+}
+
+var crcSuffixPattern = regexp.MustCompile(`6304[0-9A-Fa-f]{4}$`)
+
+// RepairPayload fixes the defects commonly found in printed or hand edited
+// payloads: line breaks, surrounding whitespace, an off-by-one length of the
+// last data object before the CRC, and a lower case, stale or missing CRC. It
+// returns the repaired payload and the repairs made, in that order.
+func RepairPayload(payload string) (string, []Repair) {
+	var repairs []Repair
+	if s := strings.NewReplacer("\r", "", "\n", "").Replace(payload); s != payload {
+		repairs = append(repairs, Repair{Rule: RepairLineBreaks, Message: "removed line breaks"})
+		payload = s
+	}
+	if s := strings.TrimSpace(payload); s != payload {
+		repairs = append(repairs, Repair{Rule: RepairWhitespace, Message: "removed leading and trailing whitespace"})
+		payload = s
+	}
+	runes := []rune(payload)
+	body, crc := runes, ""
+	if crcSuffixPattern.MatchString(payload) {
+		body, crc = runes[:len(runes)-8], string(runes[len(runes)-4:])
+	}
+	if r, ok := repairLastLength(body); ok {
+		repairs = append(repairs, r)
+	}
+	data := string(body) + IDCRC.String() + "04"
+	switch expected := checksum(data); {
+	case crc == "":
+		repairs = append(repairs, Repair{Rule: RepairCRCMissing, Tag: IDCRC.String(), Message: "appended CRC " + expected})
+	case strings.ToUpper(crc) == expected && crc != expected:
+		repairs = append(repairs, Repair{Rule: RepairCRCCase, Tag: IDCRC.String(), Message: "upper cased CRC " + crc})
+	case strings.ToUpper(crc) != expected:
+		repairs = append(repairs, Repair{Rule: RepairCRCMismatch, Tag: IDCRC.String(), Message: fmt.Sprintf("replaced CRC %s with %s", crc, expected)})
+	}
+	return data + checksum(data), repairs
+}
+
+// repairLastLength corrects, in place, the length of the last data object of
+// body when its value is one character longer or shorter than the length
+// says.
+func repairLastLength(body []rune) (Repair, bool) {
+	const header = IDWordCount + ValueLengthWordCount
+	for i := 0; len(body)-i >= header; {
+		n, err := strconv.Atoi(string(body[i+IDWordCount : i+header]))
+		if err != nil || n < 0 {
+			return Repair{}, false
+		}
+		rest := len(body) - (i + header + n)
+		switch {
+		case rest == 1 || rest == -1:
+			actual := len(body) - i - header
+			if actual > MaxValueLength {
+				return Repair{}, false
+			}
+			id := string(body[i : i+IDWordCount])
+			copy(body[i+IDWordCount:], []rune(fmt.Sprintf("%02d", actual)))
+			return Repair{Rule: RepairLength, Tag: id, Message: fmt.Sprintf("corrected length of %s from %02d to %02d", id, n, actual)}, true
+		case rest < 0:
+			return Repair{}, false
+		}
+		i += header + n
+	}
+	return Repair{}, false
+}
+
+// DecodeLenient decodes payload like DecodeWithOptions after repairing it
+// with RepairPayload. The repaired payload and the repairs are returned even
+// if decoding fails.
+func DecodeLenient(payload string, opts DecodeOptions) (*EMVQR, *Repaired, error) {
+	repaired, repairs := RepairPayload(payload)
+	r := &Repaired{Payload: repaired, Repairs: repairs}
+	opts.Lenient = false
+	c, err := DecodeWithOptions(repaired, opts)
+	if err != nil {
+		return nil, r, err
+	}
+	return c, r, nil
+}
@@ -0,0 +1,113 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepairPayload(t *testing.T) {
+	body := samplePayload[:len(samplePayload)-8]
+	tests := []struct {
+		name    string
+		payload string
+		want    []RepairRule
+	}{
+		{
+			name:    "valid",
+			payload: samplePayload,
+			want:    nil,
+		},
+		{
+			name:    "windows line endings",
+			payload: samplePayload[:80] + "\r\n" + samplePayload[80:] + "\r\n",
+			want:    []RepairRule{RepairLineBreaks},
+		},
+		{
+			name:    "whitespace",
+			payload: "  " + samplePayload + " ",
+			want:    []RepairRule{RepairWhitespace},
+		},
+		{
+			name:    "lower case crc",
+			payload: body + "6304a13a",
+			want:    []RepairRule{RepairCRCCase},
+		},
+		{
+			name:    "missing crc",
+			payload: body,
+			want:    []RepairRule{RepairCRCMissing},
+		},
+		{
+			name:    "stale crc",
+			payload: body + "6304FFFF",
+			want:    []RepairRule{RepairCRCMismatch},
+		},
+		{
+			name:    "length one short",
+			payload: strings.Replace(body, "91320016", "91310016", 1) + "6304A13A",
+			want:    []RepairRule{RepairLength},
+		},
+		{
+			name:    "length one long",
+			payload: strings.Replace(body, "91320016", "91330016", 1) + "\n",
+			want:    []RepairRule{RepairLineBreaks, RepairLength, RepairCRCMissing},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, repairs := RepairPayload(tt.payload)
+			if got != samplePayload {
+				t.Errorf("RepairPayload() = %v, want %v", got, samplePayload)
+			}
+			var rules []RepairRule
+			for _, r := range repairs {
+				rules = append(rules, r.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.want) {
+				t.Errorf("RepairPayload() repairs = %+v, want %v", repairs, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	payload := strings.Replace(samplePayload[:len(samplePayload)-8], "91320016", "91310016", 1) + "6304a13a\r\n"
+	if _, err := Decode(payload); err == nil {
+		t.Fatal("Decode() error = nil, want an error in strict mode")
+	}
+	c, r, err := DecodeLenient(payload, DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.MerchantName.Value != "BEST TRANSPORT" || r.Payload != samplePayload {
+		t.Errorf("DecodeLenient() = %v, %v", c.MerchantName.Value, r.Payload)
+	}
+	want := []Repair{
+		{Rule: RepairLineBreaks, Message: "removed line breaks"},
+		{Rule: RepairLength, Tag: "91", Message: "corrected length of 91 from 31 to 32"},
+		{Rule: RepairCRCCase, Tag: "63", Message: "upper cased CRC a13a"},
+	}
+	if !reflect.DeepEqual(r.Repairs, want) {
+		t.Errorf("DecodeLenient() repairs = %+v, want %+v", r.Repairs, want)
+	}
+	if _, err := DecodeWithOptions(payload, DecodeOptions{Lenient: true}); err != nil {
+		t.Errorf("DecodeWithOptions() error = %v", err)
+	}
+	if _, err := ParseEMVQRWithOptions(payload, DecodeOptions{Lenient: true}); err != nil {
+		t.Errorf("ParseEMVQRWithOptions() error = %v", err)
+	}
+	if tree, err := ParseTreeWithOptions(payload, DecodeOptions{Lenient: true}); err != nil {
+		t.Errorf("ParseTreeWithOptions() error = %v", err)
+	} else if s, _ := tree.GeneratePayload(); s != samplePayload {
+		t.Errorf("Tree.GeneratePayload() = %v, want %v", s, samplePayload)
+	}
+	_, r, err = DecodeLenient("0002010102", DecodeOptions{})
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("DecodeLenient() error = %v, want ErrTruncated", err)
+	}
+	if r == nil || len(r.Repairs) == 0 {
+		t.Errorf("DecodeLenient() repairs = %+v, want the repairs tried", r)
+	}
+}
@@ -0,0 +1,60 @@
+package mpm
+
+// MerchantAccountScheme is a payment scheme that EMVCo allocated primitive
+// Merchant Account Information IDs to.
+type MerchantAccountScheme struct {
+	Name  string // e.g. "Visa"
+	Start ID
+	End   ID
+	PAN   bool // whether the value is a merchant PAN
+}
+
+// merchantAccountSchemes is the EMVCo allocation of IDs "02"-"25".
+var merchantAccountSchemes = []MerchantAccountScheme{
+	{Name: "Visa", Start: "02", End: "03", PAN: true},
+	{Name: "Mastercard", Start: "04", End: "05", PAN: true},
+	{Name: "EMVCo", Start: "06", End: "08"},
+	{Name: "Discover", Start: "09", End: "10"},
+	{Name: "Amex", Start: "11", End: "12"},
+	{Name: "JCB", Start: "13", End: "14"},
+	{Name: "UnionPay", Start: "15", End: "16"},
+	{Name: "EMVCo", Start: "17", End: "25"},
+}
+
+// LookupMerchantAccountScheme returns the payment scheme of the primitive
+// Merchant Account Information id.
+func LookupMerchantAccountScheme(id ID) (MerchantAccountScheme, bool) {
+	for _, s := range merchantAccountSchemes {
+		if within, err := id.Between(s.Start, s.End); err == nil && within && len(id) == IDWordCount {
+			return s, true
+		}
+	}
+	return MerchantAccountScheme{}, false
+}
+
+// Scheme returns the name of the payment scheme of tlv, a primitive Merchant
+// Account Information, or "" if there is none.
+func (tlv TLV) Scheme() string {
+	s, _ := LookupMerchantAccountScheme(tlv.Tag)
+	return s.Name
+}
+
+// ValidPAN reports whether pan is a primary account number: 8 to 19 digits
+// with a valid Luhn check digit.
+func ValidPAN(pan string) bool {
+	if len(pan) < 8 || len(pan) > 19 || !FormatNumeric.Match(pan) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(pan); i++ {
+		d := int(pan[len(pan)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
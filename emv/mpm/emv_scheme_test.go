@@ -0,0 +1,109 @@
+package mpm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupMerchantAccountScheme(t *testing.T) {
+	tests := []struct {
+		id     ID
+		want   string
+		wantOK bool
+	}{
+		{id: "02", want: "Visa", wantOK: true},
+		{id: "05", want: "Mastercard", wantOK: true},
+		{id: "07", want: "EMVCo", wantOK: true},
+		{id: "16", want: "UnionPay", wantOK: true},
+		{id: "25", want: "EMVCo", wantOK: true},
+		{id: "26", wantOK: false},
+		{id: "ab", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			got, ok := LookupMerchantAccountScheme(tt.id)
+			if got.Name != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupMerchantAccountScheme() = %v, %v, want %v, %v", got.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidPAN(t *testing.T) {
+	tests := []struct {
+		pan  string
+		want bool
+	}{
+		{pan: "4761739001010010", want: true},
+		{pan: "5413330089010442", want: true},
+		{pan: "4761739001010011", want: false},
+		{pan: "4761", want: false},
+		{pan: "47617390010100A0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pan, func(t *testing.T) {
+			if got := ValidPAN(tt.pan); got != tt.want {
+				t.Errorf("ValidPAN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_MerchantAccountPrimitive(t *testing.T) {
+	c := validEMVQR()
+	c.MerchantAccountInformation = nil
+	c.AddMerchantAccountPrimitive("02", "4761739001010010")
+	c.AddMerchantAccountPrimitive("04", "5413330089010442")
+	payload, err := c.GeneratePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TLV{
+		{Tag: "02", Length: "16", Value: "4761739001010010"},
+		{Tag: "04", Length: "16", Value: "5413330089010442"},
+	}
+	if !reflect.DeepEqual(got.MerchantAccountPrimitives, want) {
+		t.Errorf("Decode() MerchantAccountPrimitives = %v, want %v", got.MerchantAccountPrimitives, want)
+	}
+	if s := got.MerchantAccountPrimitives[0].Scheme(); s != "Visa" {
+		t.Errorf("TLV.Scheme() = %v, want Visa", s)
+	}
+	if s, err := got.GeneratePayload(); err != nil || s != payload {
+		t.Errorf("EMVQR.GeneratePayload() = %v, %v, want %v", s, err, payload)
+	}
+}
+
+func TestEMVQR_ValidateAll_MerchantAccountPrimitive(t *testing.T) {
+	tests := []struct {
+		name string
+		id   ID
+		v    string
+		want []Violation
+	}{
+		{name: "visa", id: "02", v: "4761739001010010"},
+		{name: "no PAN", id: "11", v: "AMEX-MID-1"},
+		{
+			name: "invalid PAN",
+			id:   "04",
+			v:    "5413330089010443",
+			want: []Violation{
+				{Path: "04", Field: "MerchantAccountPrimitive", Rule: RuleValue, Severity: SeverityError, Value: "5413330089010443", Message: "MerchantAccountPrimitive should be a Mastercard PAN of 8 to 19 digits with a valid check digit, MerchantAccountPrimitive: 5413330089010443"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			c.MerchantAccountInformation = nil
+			c.AddMerchantAccountPrimitive(tt.id, tt.v)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+		})
+	}
+}
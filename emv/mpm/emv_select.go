@@ -0,0 +1,188 @@
+package mpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MerchantAccount is a Merchant Account Information of a QR code, either a
+// primitive (IDs "02"-"25") or a template (IDs "26"-"51").
+type MerchantAccount struct {
+	ID ID
+	// Network is the payment scheme of a primitive, or the network registered
+	// for the GUID of a template. It is "" if neither is known.
+	Network string
+	// GUID is the Globally Unique Identifier of a template. Its Kind is
+	// GUIDKindUnknown if the value is not a valid GUID.
+	GUID GUID
+	// Value is the value of a primitive.
+	Value string
+	// Template is the template, nil for a primitive.
+	Template *MerchantAccountInformation
+}
+
+func (a MerchantAccount) String() string {
+	if a.Network == "" {
+		return a.ID.String()
+	}
+	return a.ID.String() + " (" + a.Network + ")"
+}
+
+// MerchantAccounts returns the merchant accounts of c, the primitives first.
+func (c *EMVQR) MerchantAccounts() []MerchantAccount {
+	var accounts []MerchantAccount
+	for _, p := range c.MerchantAccountPrimitives {
+		accounts = append(accounts, MerchantAccount{ID: p.Tag, Network: p.Scheme(), Value: p.Value})
+	}
+	for _, id := range c.merchantAccountInformationIDs() {
+		m := c.MerchantAccountInformation[id].Value
+		if m == nil {
+			continue
+		}
+		a := MerchantAccount{ID: id, Template: m}
+		g, err := m.GUID()
+		if err != nil {
+			g = GUID{Value: m.GloballyUniqueIdentifier.Value}
+		}
+		a.GUID = g
+		a.Network, _ = g.Network()
+		accounts = append(accounts, a)
+	}
+	return accounts
+}
+
+// NetworkPreference is a network the consumer can pay with. A merchant account
+// matches if it matches both Network and GUID; an empty field matches any.
+type NetworkPreference struct {
+	// Network is a network name, e.g. "Mastercard" or "Pix", compared without
+	// case.
+	Network string
+	// GUID is a Globally Unique Identifier. An AID also matches the longer
+	// AIDs it is a prefix of, and a primitive of the scheme it is registered
+	// for.
+	GUID string
+	// Currencies are the Transaction Currency codes accepted, e.g. "764".
+	// Empty accepts any.
+	Currencies []string
+	// MaxAmount is the largest Transaction Amount accepted, written like the
+	// value of ID "54". Empty, or a QR code without an amount, is not
+	// checked.
+	MaxAmount string
+}
+
+func (p NetworkPreference) String() string {
+	var s []string
+	if p.Network != "" {
+		s = append(s, "network "+p.Network)
+	}
+	if p.GUID != "" {
+		s = append(s, "GUID "+p.GUID)
+	}
+	if len(s) == 0 {
+		return "any network"
+	}
+	return strings.Join(s, ", ")
+}
+
+func (p NetworkPreference) matches(a MerchantAccount) bool {
+	if p.Network != "" && !strings.EqualFold(p.Network, a.Network) {
+		return false
+	}
+	if p.GUID == "" {
+		return true
+	}
+	g, err := ParseGUID(p.GUID)
+	switch {
+	case err != nil:
+		return a.Template != nil && strings.EqualFold(p.GUID, a.GUID.Value)
+	case a.Template == nil:
+		network, ok := g.Network()
+		return ok && a.Network != "" && strings.EqualFold(network, a.Network)
+	case g.Kind == GUIDKindAID && a.GUID.Kind == GUIDKindAID:
+		return strings.HasPrefix(a.GUID.Value, g.Value)
+	}
+	return a.GUID.Kind != GUIDKindUnknown && g.key() == a.GUID.key()
+}
+
+// reject returns why c does not meet the constraints of p, or "" if it does.
+func (p NetworkPreference) reject(c *EMVQR) string {
+	currency := c.TransactionCurrency.Value
+	if len(p.Currencies) > 0 {
+		accepted := false
+		for _, v := range p.Currencies {
+			accepted = accepted || v == currency
+		}
+		if !accepted {
+			return fmt.Sprintf("TransactionCurrency %s is not accepted", currency)
+		}
+	}
+	amount := c.TransactionAmount.Value
+	if p.MaxAmount == "" || amount == "" {
+		return ""
+	}
+	v, err := ParseAmount(amount, currency, RoundExact)
+	if err != nil {
+		return err.Error()
+	}
+	max, err := ParseAmount(p.MaxAmount, currency, RoundDown)
+	if err != nil {
+		return err.Error()
+	}
+	if v > max {
+		return fmt.Sprintf("TransactionAmount %s exceeds %s", amount, p.MaxAmount)
+	}
+	return ""
+}
+
+// Selection is the merchant account chosen by SelectMerchantAccount.
+type Selection struct {
+	Account    MerchantAccount
+	Preference NetworkPreference
+	Reason     string
+}
+
+// ErrNoSupportedNetwork is returned by SelectMerchantAccount when no merchant
+// account matches a preference.
+type ErrNoSupportedNetwork struct {
+	// Rejected explains each match that failed the constraints of its
+	// preference.
+	Rejected []string
+}
+
+func (e *ErrNoSupportedNetwork) Error() string {
+	if len(e.Rejected) == 0 {
+		return "no supported network"
+	}
+	return "no supported network: " + strings.Join(e.Rejected, "; ")
+}
+
+// Is reports whether target is an *ErrNoSupportedNetwork.
+func (e *ErrNoSupportedNetwork) Is(target error) bool {
+	_, ok := target.(*ErrNoSupportedNetwork)
+	return ok
+}
+
+// SelectMerchantAccount chooses the merchant account of c to pay with: the
+// first account, in the order of MerchantAccounts, that matches the first
+// preference it can meet the constraints of.
+func (c *EMVQR) SelectMerchantAccount(prefs []NetworkPreference) (*Selection, error) {
+	accounts := c.MerchantAccounts()
+	e := &ErrNoSupportedNetwork{}
+	for i, p := range prefs {
+		for _, a := range accounts {
+			if !p.matches(a) {
+				continue
+			}
+			if reason := p.reject(c); reason != "" {
+				e.Rejected = append(e.Rejected, fmt.Sprintf("merchant account %s matches preference %d (%s) but %s", a, i+1, p, reason))
+				continue
+			}
+			return &Selection{
+				Account:    a,
+				Preference: p,
+				Reason:     fmt.Sprintf("merchant account %s matches preference %d (%s)", a, i+1, p),
+			}, nil
+		}
+	}
+	return nil, e
+}
@@ -0,0 +1,105 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func selectEMVQR() *EMVQR {
+	c := validEMVQR()
+	c.MerchantAccountInformation = nil
+	c.AddMerchantAccountPrimitive("04", "5413330089010442")
+	jcb := new(MerchantAccountInformation)
+	jcb.SetGloballyUniqueIdentifier("D123456")
+	jcb.AddPaymentNetworkSpecific("13", "JCB1234567890")
+	c.AddMerchantAccountInformation(ID("29"), jcb)
+	pix := new(MerchantAccountInformation)
+	pix.SetGloballyUniqueIdentifier("br.gov.bcb.pix")
+	pix.AddPaymentNetworkSpecific("01", "merchant@example.com")
+	c.AddMerchantAccountInformation(ID("26"), pix)
+	unionPay := new(MerchantAccountInformation)
+	unionPay.SetGloballyUniqueIdentifier("A000000333010101")
+	c.AddMerchantAccountInformation(ID("31"), unionPay)
+	return c
+}
+
+func TestEMVQR_MerchantAccounts(t *testing.T) {
+	var got []string
+	for _, a := range selectEMVQR().MerchantAccounts() {
+		got = append(got, a.String())
+	}
+	want := []string{"04 (Mastercard)", "26 (Pix)", "29", "31 (UnionPay)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EMVQR.MerchantAccounts() = %v, want %v", got, want)
+	}
+}
+
+func TestEMVQR_SelectMerchantAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefs      []NetworkPreference
+		wantID     ID
+		wantReason string
+		wantErr    string
+	}{
+		{
+			name:       "first preference",
+			prefs:      []NetworkPreference{{Network: "pix"}, {Network: "Mastercard"}},
+			wantID:     "26",
+			wantReason: "merchant account 26 (Pix) matches preference 1 (network pix)",
+		},
+		{
+			name:       "primitive by network",
+			prefs:      []NetworkPreference{{Network: "Visa"}, {Network: "Mastercard"}},
+			wantID:     "04",
+			wantReason: "merchant account 04 (Mastercard) matches preference 2 (network Mastercard)",
+		},
+		{
+			name:       "primitive by RID",
+			prefs:      []NetworkPreference{{GUID: "A000000004"}},
+			wantID:     "04",
+			wantReason: "merchant account 04 (Mastercard) matches preference 1 (GUID A000000004)",
+		},
+		{
+			name:       "AID prefix",
+			prefs:      []NetworkPreference{{GUID: "a000000333"}},
+			wantID:     "31",
+			wantReason: "merchant account 31 (UnionPay) matches preference 1 (GUID a000000333)",
+		},
+		{
+			name:       "unregistered GUID",
+			prefs:      []NetworkPreference{{GUID: "D123456"}},
+			wantID:     "29",
+			wantReason: "merchant account 29 matches preference 1 (GUID D123456)",
+		},
+		{
+			name:       "constraints",
+			prefs:      []NetworkPreference{{Network: "Pix", Currencies: []string{"986"}}, {Network: "UnionPay", MaxAmount: "20"}, {Network: "UnionPay", Currencies: []string{"156"}, MaxAmount: "100.00"}},
+			wantID:     "31",
+			wantReason: "merchant account 31 (UnionPay) matches preference 3 (network UnionPay)",
+		},
+		{
+			name:    "no supported network",
+			prefs:   []NetworkPreference{{Network: "Visa"}, {Network: "Pix", Currencies: []string{"986"}}},
+			wantErr: "no supported network: merchant account 26 (Pix) matches preference 2 (network Pix) but TransactionCurrency 156 is not accepted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectEMVQR().SelectMerchantAccount(tt.prefs)
+			if tt.wantErr != "" {
+				if !errors.Is(err, &ErrNoSupportedNetwork{}) || err.Error() != tt.wantErr {
+					t.Errorf("EMVQR.SelectMerchantAccount() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Account.ID != tt.wantID || got.Reason != tt.wantReason {
+				t.Errorf("EMVQR.SelectMerchantAccount() = %v, %q, want %v, %q", got.Account.ID, got.Reason, tt.wantID, tt.wantReason)
+			}
+		})
+	}
+}
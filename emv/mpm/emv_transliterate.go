@@ -0,0 +1,324 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations are the characters whose decomposition does not lead to
+// a usable ASCII letter, or would lose part of it, e.g. "й".
+var transliterations = map[rune]string{
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Ø': "O", 'ø': "o", 'Œ': "OE", 'œ': "oe",
+	'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d", 'Þ': "TH", 'þ': "th", 'Ł': "L",
+	'ł': "l", 'Ħ': "H", 'ħ': "h", 'ı': "i", 'Ŋ': "NG", 'ŋ': "ng",
+	'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"",
+	'«': "\"", '»': "\"", '–': "-", '—': "-", '・': " ", '、': ",", '。': ".",
+	'Й': "Y", 'й': "y", 'Ё': "E", 'ё': "e",
+}
+
+// cyrillic romanises Russian, Ukrainian and Belarusian letters.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh",
+	'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ґ': "g", 'ў': "u",
+}
+
+// greek romanises Greek letters.
+var greek = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// kana romanises hiragana (Hepburn). Katakana are mapped to hiragana first.
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゎ': "wa",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo",
+}
+
+// Revised Romanization of the initial, medial and final jamo of a Hangul
+// syllable.
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+// ErrNoTransliteration is returned by SetLocalizedMerchant for a name or
+// city that Transliterate cannot romanise completely and that has no ASCII
+// version.
+var ErrNoTransliteration = errors.New("no transliteration")
+
+// Transliterate returns s in the common character set (0x20-0x7E): accents
+// are removed, Latin ligatures are spelled out, and Greek, Cyrillic, kana
+// and Hangul are romanised. Characters of other scripts are dropped: there
+// is no romanisation of Han (e.g. pinyin) or Thai (e.g. RTGS), so "ร้าน ABC"
+// becomes "ABC". Runs of spaces are collapsed.
+func Transliterate(s string) string {
+	t, _ := transliterate(s)
+	return t
+}
+
+// transliterate returns Transliterate(s), and false if a letter or digit of
+// s was dropped. Modifier letters, such as the long vowel mark "ー", do not
+// count.
+func transliterate(s string) (string, bool) {
+	var b strings.Builder
+	complete := true
+	runes := []rune(norm.NFC.String(s))
+	for i := 0; i < len(runes); i++ {
+		r := hiragana(runes[i])
+		switch {
+		case r >= 0x20 && r <= 0x7E:
+			b.WriteRune(r)
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		case r == 'っ':
+			// sokuon doubles the next consonant
+			if i+1 < len(runes) {
+				next, _ := romaniseKana(runes[i+1:])
+				if strings.HasPrefix(next, "ch") {
+					b.WriteByte('t')
+				} else if next != "" && !strings.ContainsRune("aiueon", rune(next[0])) {
+					b.WriteByte(next[0])
+				}
+			}
+		case kana[r] != "":
+			k, n := romaniseKana(runes[i:])
+			b.WriteString(k)
+			i += n - 1
+		case r >= 0xAC00 && r <= 0xD7A3:
+			n := int(r - 0xAC00)
+			b.WriteString(hangulInitials[n/588] + hangulMedials[n%588/28] + hangulFinals[n%28])
+		case unicode.IsSpace(r):
+			b.WriteByte(' ')
+		default:
+			for _, d := range norm.NFKD.String(string(r)) {
+				if unicode.Is(unicode.Mn, d) {
+					continue
+				}
+				v := romaniseLetter(d)
+				if v == "" && (unicode.In(d, unicode.Lu, unicode.Ll, unicode.Lo) || unicode.IsDigit(d)) && !isRomanisedLetter(d) {
+					complete = false
+				}
+				b.WriteString(v)
+			}
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " "), complete
+}
+
+// isRomanisedLetter reports whether r is a Greek or Cyrillic letter, some of
+// which, like "ь", romanise to nothing.
+func isRomanisedLetter(r rune) bool {
+	lower := unicode.ToLower(r)
+	_, ok := cyrillic[lower]
+	if !ok {
+		_, ok = greek[lower]
+	}
+	return ok
+}
+
+// romaniseKana romanises the kana at the start of runes, combined with a
+// following small "ya", "yu" or "yo", and returns the number of runes used.
+func romaniseKana(runes []rune) (string, int) {
+	k := kana[hiragana(runes[0])]
+	if len(runes) < 2 || !isSmallYKana(runes[1]) || len(k) < 2 || !strings.HasSuffix(k, "i") {
+		return k, 1
+	}
+	y := kana[hiragana(runes[1])]
+	switch k {
+	case "shi", "chi", "ji":
+		return k[:len(k)-1] + y[1:], 2
+	}
+	return k[:len(k)-1] + y, 2
+}
+
+// hiragana maps a katakana to the hiragana of the same sound.
+func hiragana(r rune) rune {
+	if r >= 0x30A1 && r <= 0x30F6 {
+		return r - 0x60
+	}
+	return r
+}
+
+func isSmallYKana(r rune) bool {
+	switch r {
+	case 'ゃ', 'ゅ', 'ょ', 'ャ', 'ュ', 'ョ':
+		return true
+	}
+	return false
+}
+
+// romaniseLetter returns r if it is in the common character set, its
+// romanisation if it is a Greek or Cyrillic letter, or "".
+func romaniseLetter(r rune) string {
+	if r >= 0x20 && r <= 0x7E {
+		return string(r)
+	}
+	lower := unicode.ToLower(r)
+	v, ok := cyrillic[lower]
+	if !ok {
+		v, ok = greek[lower]
+	}
+	if !ok || v == "" || lower == r {
+		return v
+	}
+	return strings.ToUpper(v[:1]) + v[1:]
+}
+
+// truncate shortens s to at most max characters, at a word boundary if one
+// is in the second half, without trailing spaces or punctuation.
+func truncate(s string, max int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= max {
+		return string(runes)
+	}
+	cut := runes[:max]
+	if runes[max] != ' ' {
+		for i := max - 1; i > max/2; i-- {
+			if cut[i] == ' ' {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRight(string(cut), " -,.&/")
+}
+
+// scriptLanguages are the languages assumed for a name in a script, in the
+// order they are tried. Kana come before Han so that Japanese names with
+// kanji are detected as Japanese.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	code   string
+}{
+	{unicode.Hiragana, "JA"},
+	{unicode.Katakana, "JA"},
+	{unicode.Hangul, "KO"},
+	{unicode.Han, "ZH"},
+	{unicode.Thai, "TH"},
+	{unicode.Lao, "LO"},
+	{unicode.Khmer, "KM"},
+	{unicode.Myanmar, "MY"},
+	{unicode.Cyrillic, "RU"},
+	{unicode.Greek, "EL"},
+	{unicode.Arabic, "AR"},
+	{unicode.Hebrew, "HE"},
+	{unicode.Devanagari, "HI"},
+	{unicode.Bengali, "BN"},
+	{unicode.Tamil, "TA"},
+	{unicode.Sinhala, "SI"},
+	{unicode.Georgian, "KA"},
+	{unicode.Armenian, "HY"},
+}
+
+// detectLanguage returns the language of the script s is written in, or ""
+// for Latin script.
+func detectLanguage(s string) string {
+	for _, l := range scriptLanguages {
+		for _, r := range s {
+			if unicode.Is(l.script, r) {
+				return l.code
+			}
+		}
+	}
+	return ""
+}
+
+// LocalizedMerchant is a merchant name and city in any script.
+type LocalizedMerchant struct {
+	Name string
+	City string
+	// Language is the language of Name and City, an ISO 639 code or a BCP
+	// 47 tag. If empty it is detected from the script, which fails for Latin
+	// script.
+	Language string
+	// ASCIIName and ASCIICity are used instead of the transliterations of
+	// Name and City. They are needed for Han and Thai, which Transliterate
+	// drops.
+	ASCIIName string
+	ASCIICity string
+}
+
+// SetLocalizedMerchant sets the Merchant Name (ID "59") and Merchant City (ID
+// "60") of c to the transliterations of m, truncated to 25 and 15
+// characters. If m is not in the common character set, the Merchant
+// Information - Language Template (ID "64") is set to m, NFC normalised;
+// otherwise it is removed. A name or city that Transliterate cannot romanise
+// completely, such as one in Han or Thai script, needs ASCIIName or
+// ASCIICity, or ErrNoTransliteration is returned.
+func (c *EMVQR) SetLocalizedMerchant(m LocalizedMerchant) error {
+	name, city := norm.NFC.String(strings.TrimSpace(m.Name)), norm.NFC.String(strings.TrimSpace(m.City))
+	asciiName, err := asciiVersion(name, m.ASCIIName, "merchant name", "ASCIIName")
+	if err != nil {
+		return err
+	}
+	asciiCity, err := asciiVersion(city, m.ASCIICity, "merchant city", "ASCIICity")
+	if err != nil {
+		return err
+	}
+	asciiName, asciiCity = truncate(asciiName, 25), truncate(asciiCity, 15)
+	if FormatAlphanumericSpecial.Match(name) && FormatAlphanumericSpecial.Match(city) {
+		c.SetMerchantName(asciiName)
+		c.SetMerchantCity(asciiCity)
+		c.MerchantInformationLanguageTemplate = nil
+		return nil
+	}
+	lang := detectLanguage(name + city)
+	if m.Language != "" {
+		l, ok := LookupLanguage(m.Language)
+		if !ok {
+			return fmt.Errorf("language should be an ISO 639 code, language: %s: %w", m.Language, ErrInvalidFormat)
+		}
+		lang = strings.ToUpper(l.Code)
+	}
+	if lang == "" {
+		return fmt.Errorf("language of merchant name is unknown, set Language, merchant name: %s: %w", name, ErrInvalidFormat)
+	}
+	t := new(MerchantInformationLanguageTemplate)
+	t.SetLanguagePreference(lang)
+	t.SetMerchantName(truncate(name, 25))
+	if city != "" {
+		t.SetMerchantCity(truncate(city, 15))
+	}
+	c.SetMerchantName(asciiName)
+	c.SetMerchantCity(asciiCity)
+	c.SetMerchantInformationLanguageTemplate(t)
+	return nil
+}
+
+// asciiVersion returns the transliteration of ascii, or of s if ascii is
+// empty, which must be complete and not empty.
+func asciiVersion(s, ascii, what, field string) (string, error) {
+	if ascii == "" {
+		ascii = s
+	}
+	t, complete := transliterate(ascii)
+	if t == "" || !complete {
+		return "", fmt.Errorf("%s has no transliteration, set %s, %s: %s: %w", what, field, what, s, ErrNoTransliteration)
+	}
+	return t, nil
+}
@@ -0,0 +1,143 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "BEST TRANSPORT", want: "BEST TRANSPORT"},
+		{s: "Café Crème", want: "Cafe Creme"},
+		{s: "Cafe\u0301", want: "Cafe"},
+		{s: "Straße Bäckerei", want: "Strasse Backerei"},
+		{s: "Łódź", want: "Lodz"},
+		{s: "ＡＢＣ　Ｍａｒｔ", want: "ABC Mart"},
+		{s: "Москва", want: "Moskva"},
+		{s: "Αθήνα", want: "Athina"},
+		{s: "とうきょう", want: "toukyou"},
+		{s: "サッポロ ラーメン", want: "sapporo ramen"},
+		{s: "マッチャ", want: "matcha"},
+		{s: "서울", want: "seoul"},
+		{s: "最佳运输", want: ""},
+		{s: "ร้าน ABC", want: "ABC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := Transliterate(tt.s); got != tt.want {
+				t.Errorf("Transliterate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{s: "BEIJING", max: 15, want: "BEIJING"},
+		{s: "THE GRAND BUDAPEST HOTEL AND SPA", max: 25, want: "THE GRAND BUDAPEST HOTEL"},
+		{s: "SUPERCALIFRAGILISTICEXPIALIDOCIOUS", max: 15, want: "SUPERCALIFRAGIL"},
+		{s: "BANGKOK NOI - SIRIRAJ", max: 15, want: "BANGKOK NOI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := truncate(tt.s, tt.max); got != tt.want {
+				t.Errorf("truncate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEMVQR_SetLocalizedMerchant(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        LocalizedMerchant
+		wantName string
+		wantCity string
+		want64   *MerchantInformationLanguageTemplate
+		wantErr  error
+	}{
+		{
+			name:     "ascii",
+			m:        LocalizedMerchant{Name: "BEST TRANSPORT", City: "BEIJING"},
+			wantName: "BEST TRANSPORT",
+			wantCity: "BEIJING",
+		},
+		{
+			name:     "kana",
+			m:        LocalizedMerchant{Name: "サッポロ ラーメン", City: "とうきょう"},
+			wantName: "sapporo ramen",
+			wantCity: "toukyou",
+			want64: &MerchantInformationLanguageTemplate{
+				LanguagePreference: TLV{Tag: "00", Length: "02", Value: "JA"},
+				MerchantName:       TLV{Tag: "01", Length: "09", Value: "サッポロ ラーメン"},
+				MerchantCity:       TLV{Tag: "02", Length: "05", Value: "とうきょう"},
+			},
+		},
+		{
+			name:     "thai with ascii name",
+			m:        LocalizedMerchant{Name: "ร้านกาแฟ", City: "กรุงเทพ", ASCIIName: "RAN KAFAE", ASCIICity: "BANGKOK"},
+			wantName: "RAN KAFAE",
+			wantCity: "BANGKOK",
+			want64: &MerchantInformationLanguageTemplate{
+				LanguagePreference: TLV{Tag: "00", Length: "02", Value: "TH"},
+				MerchantName:       TLV{Tag: "01", Length: "08", Value: "ร้านกาแฟ"},
+				MerchantCity:       TLV{Tag: "02", Length: "07", Value: "กรุงเทพ"},
+			},
+		},
+		{
+			name:     "accented latin",
+			m:        LocalizedMerchant{Name: "Cafe\u0301 Crème", City: "Paris", Language: "fr-FR"},
+			wantName: "Cafe Creme",
+			wantCity: "Paris",
+			want64: &MerchantInformationLanguageTemplate{
+				LanguagePreference: TLV{Tag: "00", Length: "02", Value: "FR"},
+				MerchantName:       TLV{Tag: "01", Length: "10", Value: "Café Crème"},
+				MerchantCity:       TLV{Tag: "02", Length: "05", Value: "Paris"},
+			},
+		},
+		{
+			name:    "accented latin without language",
+			m:       LocalizedMerchant{Name: "Café", City: "Paris"},
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "han without ascii name",
+			m:       LocalizedMerchant{Name: "最佳运输", City: "北京", ASCIICity: "BEIJING"},
+			wantErr: ErrNoTransliteration,
+		},
+		{
+			name:    "thai and latin without ascii name",
+			m:       LocalizedMerchant{Name: "ร้าน ABC", City: "BANGKOK"},
+			wantErr: ErrNoTransliteration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			err := c.SetLocalizedMerchant(tt.m)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("EMVQR.SetLocalizedMerchant() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.MerchantName.Value != tt.wantName || c.MerchantCity.Value != tt.wantCity {
+				t.Errorf("EMVQR.SetLocalizedMerchant() = %q, %q, want %q, %q", c.MerchantName.Value, c.MerchantCity.Value, tt.wantName, tt.wantCity)
+			}
+			if !reflect.DeepEqual(c.MerchantInformationLanguageTemplate, tt.want64) {
+				t.Errorf("MerchantInformationLanguageTemplate = %+v, want %+v", c.MerchantInformationLanguageTemplate, tt.want64)
+			}
+			if err := c.Validate(); err != nil {
+				t.Errorf("EMVQR.Validate() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Node is a data object of a Tree. A template has Children instead of a
+// Value.
+type Node struct {
+	ID       ID      `json:"id"`
+	Value    string  `json:"value,omitempty"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// Tree is a generic representation of an MPM payload: a node per data
+// object, with children for the templates (IDs 26-51, 62, 62.50-62.99, 64
+// and 80-99).
+// Data objects are addressed by tag path, e.g. "62.05" for the Reference
+// Label of the Additional Data Field Template.
+type Tree struct {
+	Nodes []*Node `json:"nodes"`
+}
+
+// ErrNotTemplate is returned when a tag path descends into a data object
+// that is not a template.
+var ErrNotTemplate = errors.New("not a template")
+
+// IsTemplate reports whether n has children.
+func (n *Node) IsTemplate() bool {
+	return n.Children != nil
+}
+
+// Data returns the value of n. For a template this is its encoded children.
+func (n *Node) Data() string {
+	if !n.IsTemplate() {
+		return n.Value
+	}
+	s := ""
+	for _, c := range n.Children {
+		s += c.String()
+	}
+	return s
+}
+
+func (n *Node) String() string {
+	return format(n.ID, n.Data())
+}
+
+// isTemplateID reports whether id is a template inside the template at
+// parent, "" being the payload itself.
+func isTemplateID(parent string, id ID) bool {
+	if parent == IDAdditionalDataFieldTemplate.String() {
+		within, err := id.Between(AdditionalIDPaymentSystemSpecificTemplatesRangeStart, AdditionalIDPaymentSystemSpecificTemplatesRangeEnd)
+		return err == nil && within
+	}
+	if parent != "" {
+		return false
+	}
+	switch id {
+	case IDAdditionalDataFieldTemplate, IDMerchantInformationLanguageTemplate:
+		return true
+	}
+	for _, r := range [][2]ID{
+		{"26", IDMerchantAccountInformationRangeEnd},
+		{IDUnreservedTemplatesRangeStart, IDUnreservedTemplatesRangeEnd},
+	} {
+		if within, err := id.Between(r[0], r[1]); err == nil && within {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTree parses payload into a Tree. The CRC is checked as in
+// ParseEMVQR.
+func ParseTree(payload string) (*Tree, error) {
+	return ParseTreeWithOptions(payload, DecodeOptions{})
+}
+
+// ParseTreeWithOptions parses payload into a Tree. A Tree keeps every data
+// object, so opts.Lossless and opts.Duplicates have no effect. With
+// opts.Lenient, payload is repaired with RepairPayload first.
+func ParseTreeWithOptions(payload string, opts DecodeOptions) (*Tree, error) {
+	if opts.Lenient {
+		payload, _ = RepairPayload(payload)
+	}
+	p := NewParser(payload)
+	t := &Tree{}
+	crcOffset := int64(-1)
+	crcNotLast := false
+	crc := ""
+	for p.Next() {
+		if crcOffset >= 0 {
+			crcNotLast = true
+		}
+		id := p.ID()
+		value := p.Value()
+		if id == IDCRC {
+			crcOffset = p.current
+			crc = value
+		}
+		t.Nodes = append(t.Nodes, newNode("", id, value))
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if crcOffset >= 0 && !opts.SkipCRCCheck {
+		if crcNotLast {
+			return nil, ErrCRCNotLast
+		}
+		if err := verifyCRC(p.source, crcOffset, crc); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// newNode returns the node for the data object id in the template at
+// parent. A template value that does not parse is kept as a plain value.
+func newNode(parent string, id ID, value string) *Node {
+	n := &Node{ID: id}
+	if !isTemplateID(parent, id) {
+		n.Value = value
+		return n
+	}
+	children, err := parseNodes(tagPath(parent, id), value)
+	if err != nil {
+		n.Value = value
+		return n
+	}
+	n.Children = children
+	return n
+}
+
+func parseNodes(parent string, value string) ([]*Node, error) {
+	p := NewParser(value)
+	children := []*Node{}
+	for p.Next() {
+		id := p.ID()
+		value := p.Value()
+		children = append(children, newNode(parent, id, value))
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// splitPath splits a tag path such as "62.05" into its IDs.
+func splitPath(path string) ([]ID, error) {
+	segments := strings.Split(path, ".")
+	ids := make([]ID, 0, len(segments))
+	for _, s := range segments {
+		if utf8.RuneCountInString(s) != IDWordCount {
+			return nil, fmt.Errorf("tag path invalid. path: %s: %w", path, ErrInvalidFormat)
+		}
+		ids = append(ids, ID(s))
+	}
+	return ids, nil
+}
+
+// Node returns the first node at path, or nil if there is none.
+func (t *Tree) Node(path string) *Node {
+	ids, err := splitPath(path)
+	if err != nil {
+		return nil
+	}
+	nodes := t.Nodes
+	var found *Node
+	for _, id := range ids {
+		if found = findNode(nodes, id); found == nil {
+			return nil
+		}
+		nodes = found.Children
+	}
+	return found
+}
+
+// Get returns the value at path. For a template this is its encoded
+// children.
+func (t *Tree) Get(path string) (string, bool) {
+	n := t.Node(path)
+	if n == nil {
+		return "", false
+	}
+	return n.Data(), true
+}
+
+// Set sets the value at path, adding the data object and any missing
+// templates on the way. A new data object is placed before the first
+// sibling with a greater ID. Setting a template parses value into its
+// children.
+func (t *Tree) Set(path string, value string) error {
+	ids, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(value) > MaxValueLength {
+		return fmt.Errorf("value too long. path: %s, length: %d: %w", path, utf8.RuneCountInString(value), ErrInvalidFormat)
+	}
+	nodes := &t.Nodes
+	parent := ""
+	for i, id := range ids {
+		last := i == len(ids)-1
+		n := findNode(*nodes, id)
+		if n == nil {
+			n = &Node{ID: id}
+			if !last {
+				if !isTemplateID(parent, id) {
+					return fmt.Errorf("tag path invalid. path: %s: %w", tagPath(parent, id), ErrNotTemplate)
+				}
+				n.Children = []*Node{}
+			}
+			*nodes = insertNode(*nodes, n)
+		}
+		if last {
+			*n = *newNode(parent, id, value)
+			return nil
+		}
+		if !n.IsTemplate() {
+			return fmt.Errorf("tag path invalid. path: %s: %w", tagPath(parent, id), ErrNotTemplate)
+		}
+		nodes = &n.Children
+		parent = tagPath(parent, id)
+	}
+	return nil
+}
+
+// Delete removes the first data object at path. It reports whether there
+// was one.
+func (t *Tree) Delete(path string) bool {
+	ids, err := splitPath(path)
+	if err != nil {
+		return false
+	}
+	nodes := &t.Nodes
+	for i, id := range ids {
+		j := indexNode(*nodes, id)
+		if j < 0 {
+			return false
+		}
+		if i == len(ids)-1 {
+			*nodes = append((*nodes)[:j], (*nodes)[j+1:]...)
+			return true
+		}
+		nodes = &(*nodes)[j].Children
+	}
+	return false
+}
+
+func indexNode(nodes []*Node, id ID) int {
+	for i, n := range nodes {
+		if n.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func findNode(nodes []*Node, id ID) *Node {
+	if i := indexNode(nodes, id); i >= 0 {
+		return nodes[i]
+	}
+	return nil
+}
+
+func insertNode(nodes []*Node, n *Node) []*Node {
+	i := len(nodes)
+	for j, s := range nodes {
+		if s.ID > n.ID {
+			i = j
+			break
+		}
+	}
+	nodes = append(nodes, nil)
+	copy(nodes[i+1:], nodes[i:])
+	nodes[i] = n
+	return nodes
+}
+
+// GeneratePayload encodes t, recomputing every length and the CRC. The CRC
+// is always the last data object.
+func (t *Tree) GeneratePayload() (string, error) {
+	s := ""
+	for _, n := range t.Nodes {
+		if n.ID == IDCRC {
+			continue
+		}
+		if err := n.check(""); err != nil {
+			return "", err
+		}
+		s += n.String()
+	}
+	s += formatCrc(s)
+	return s, nil
+}
+
+// check reports a value that does not fit in a two digit length field.
+func (n *Node) check(parent string) error {
+	path := tagPath(parent, n.ID)
+	for _, c := range n.Children {
+		if err := c.check(path); err != nil {
+			return err
+		}
+	}
+	if l := utf8.RuneCountInString(n.Data()); l > MaxValueLength {
+		return fmt.Errorf("value too long. path: %s, length: %d: %w", path, l, ErrInvalidFormat)
+	}
+	return nil
+}
+
+// Tree returns the generic representation of c.
+func (c *EMVQR) Tree() (*Tree, error) {
+	payload, err := c.GeneratePayload()
+	if err != nil {
+		return nil, err
+	}
+	return ParseTree(payload)
+}
+
+// EMVQR converts t into an EMVQR. Data objects that EMVQR does not model
+// are kept as by a lossless decode; a repeated ID is an error wrapping
+// ErrDuplicateTag.
+func (t *Tree) EMVQR() (*EMVQR, error) {
+	payload, err := t.GeneratePayload()
+	if err != nil {
+		return nil, err
+	}
+	return ParseEMVQRWithOptions(payload, DecodeOptions{Lossless: true, Duplicates: DuplicateReject})
+}
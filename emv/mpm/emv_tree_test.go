@@ -0,0 +1,200 @@
+package mpm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const samplePayload = "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304A13A"
+
+func TestParseTree(t *testing.T) {
+	got, err := ParseTree("000201" + format("62", "0503123") + "6304" + checksum("000201"+format("62", "0503123")+"6304"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Tree{
+		Nodes: []*Node{
+			{ID: "00", Value: "01"},
+			{ID: "62", Children: []*Node{{ID: "05", Value: "123"}}},
+			{ID: "63", Value: checksum("000201" + format("62", "0503123") + "6304")},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTree() = %+v, want %+v", got, want)
+	}
+	if _, err := ParseTree("0002016304FFFF"); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("ParseTree() error = %v, want %v", err, ErrCRCMismatch)
+	}
+}
+
+func TestTree_GeneratePayload(t *testing.T) {
+	tree, err := ParseTree(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tree.GeneratePayload(); err != nil || got != samplePayload {
+		t.Errorf("Tree.GeneratePayload() = %v, %v, want %v", got, err, samplePayload)
+	}
+}
+
+func TestTree_Get(t *testing.T) {
+	tree, err := ParseTree(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "59", want: "BEST TRANSPORT", wantOK: true},
+		{path: "62.05", want: "", wantOK: false},
+		{path: "62.07", want: "A6008667", wantOK: true},
+		{path: "64.01", want: "最佳运输", wantOK: true},
+		{path: "29", want: "0012D156000000000510A93FO3230Q", wantOK: true},
+		{path: "29.05", want: "A93FO3230Q", wantOK: true},
+		{path: "59.01", want: "", wantOK: false},
+		{path: "6", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := tree.Get(tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Tree.Get() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTree_SetDelete(t *testing.T) {
+	body := "000201" + format("29", "0004hoge") + "5802JP" + "5903ABC"
+	tests := []struct {
+		name    string
+		edit    func(tree *Tree) error
+		want    string
+		wantErr error
+	}{
+		{
+			name: "set primitive",
+			edit: func(tree *Tree) error { return tree.Set("59", "DONGRI") },
+			want: "000201" + format("29", "0004hoge") + "5802JP" + "5906DONGRI",
+		},
+		{
+			name: "set nested",
+			edit: func(tree *Tree) error { return tree.Set("29.01", "abc") },
+			want: "000201" + format("29", "0004hoge0103abc") + "5802JP" + "5903ABC",
+		},
+		{
+			name: "set new template",
+			edit: func(tree *Tree) error { return tree.Set("62.05", "123") },
+			want: body + format("62", "0503123"),
+		},
+		{
+			name: "set new data object in order",
+			edit: func(tree *Tree) error { return tree.Set("53", "392") },
+			want: "000201" + format("29", "0004hoge") + "5303392" + "5802JP" + "5903ABC",
+		},
+		{
+			name: "set template value",
+			edit: func(tree *Tree) error { return tree.Set("29", "0004fuga") },
+			want: "000201" + format("29", "0004fuga") + "5802JP" + "5903ABC",
+		},
+		{
+			name:    "set below primitive",
+			edit:    func(tree *Tree) error { return tree.Set("59.01", "x") },
+			want:    body,
+			wantErr: ErrNotTemplate,
+		},
+		{
+			name:    "set invalid path",
+			edit:    func(tree *Tree) error { return tree.Set("5", "x") },
+			want:    body,
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name: "delete",
+			edit: func(tree *Tree) error {
+				if !tree.Delete("58") {
+					return errors.New("not deleted")
+				}
+				return nil
+			},
+			want: "000201" + format("29", "0004hoge") + "5903ABC",
+		},
+		{
+			name: "delete nested",
+			edit: func(tree *Tree) error {
+				if !tree.Delete("29.00") {
+					return errors.New("not deleted")
+				}
+				return nil
+			},
+			want: "000201" + format("29", "") + "5802JP" + "5903ABC",
+		},
+		{
+			name: "delete missing",
+			edit: func(tree *Tree) error {
+				if tree.Delete("54") || tree.Delete("62.05") {
+					return errors.New("deleted")
+				}
+				return nil
+			},
+			want: body,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseTree(withCRC(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.edit(tree); !errors.Is(err, tt.wantErr) {
+				t.Errorf("edit error = %v, want %v", err, tt.wantErr)
+			}
+			got, err := tree.GeneratePayload()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := withCRC(tt.want); got != want {
+				t.Errorf("Tree.GeneratePayload() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTree_EMVQR(t *testing.T) {
+	c, err := Decode(samplePayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Set("62.05", "REF1"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := tree.EMVQR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AdditionalDataFieldTemplate.ReferenceLabel.Value != "REF1" {
+		t.Errorf("EMVQR.AdditionalDataFieldTemplate.ReferenceLabel = %v, want REF1", got.AdditionalDataFieldTemplate.ReferenceLabel)
+	}
+	if got.MerchantName.Value != "BEST TRANSPORT" {
+		t.Errorf("EMVQR.MerchantName = %v, want BEST TRANSPORT", got.MerchantName)
+	}
+}
+
+func TestTree_EMVQR_Duplicates(t *testing.T) {
+	tree, err := ParseTree(withCRC("000201" + "540410.0" + "5403999"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tree.EMVQR()
+	var perr *ParserError
+	if !errors.Is(err, ErrDuplicateTag) || !errors.As(err, &perr) || perr.Path != "54" {
+		t.Errorf("Tree.EMVQR() error = %v, want ErrDuplicateTag at 54", err)
+	}
+}
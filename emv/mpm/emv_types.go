@@ -9,8 +9,6 @@ import (
 	"strconv"
 	"strings"
 	"unicode/utf8"
-
-	"github.com/dongri/emv-qrcode/crc16"
 )
 
 // const ...
@@ -38,6 +36,16 @@ const (
 	IDUnreservedTemplatesRangeEnd          ID = "99" // (O) 80-99 Unreserved Templates
 )
 
+// Merchant Account Information (IDs "02"-"51"): primitive data objects that
+// hold a payment network identifier, such as a merchant PAN, in 02-25 and
+// templates in 26-51.
+const (
+	IDMerchantAccountPrimitiveRangeStart ID = "02" // (O) 02-25 Merchant Account Information, primitive
+	IDMerchantAccountPrimitiveRangeEnd   ID = "25" // (O) 02-25 Merchant Account Information, primitive
+	IDMerchantAccountTemplateRangeStart  ID = "26" // (O) 26-51 Merchant Account Information, template
+	IDMerchantAccountTemplateRangeEnd    ID = "51" // (O) 26-51 Merchant Account Information, template
+)
+
 // Data Object ID Allocation in Merchant Account Information Template ...
 const (
 	MerchantAccountInformationIDGloballyUniqueIdentifier    ID = "00"
@@ -56,12 +64,21 @@ const (
 	AdditionalIDTerminalLabel                            ID = "07" // (O) Terminal Label
 	AdditionalIDPurposeTransaction                       ID = "08" // (O) Purpose Transaction
 	AdditionalIDAdditionalConsumerDataRequest            ID = "09" // (O) Additional Consumer Data Request
-	AdditionalIDRFUforEMVCoRangeStart                    ID = "10" // (O) RFU for EMVCo
+	AdditionalIDMerchantTaxID                            ID = "10" // (O) Merchant Tax ID
+	AdditionalIDMerchantChannel                          ID = "11" // (O) Merchant Channel
+	AdditionalIDRFUforEMVCoRangeStart                    ID = "12" // (O) RFU for EMVCo
 	AdditionalIDRFUforEMVCoRangeEnd                      ID = "49" // (O) RFU for EMVCo
 	AdditionalIDPaymentSystemSpecificTemplatesRangeStart ID = "50" // (O) Payment System Specific Templates
 	AdditionalIDPaymentSystemSpecificTemplatesRangeEnd   ID = "99" // (O) Payment System Specific Templates
 )
 
+// Data Objects for Payment System Specific Templates (IDs "62.50"-"62.99")
+const (
+	PaymentSystemSpecificIDGloballyUniqueIdentifier ID = "00"
+	PaymentSystemSpecificIDDataStart                ID = "01" // (O) 01-99 Payment System specific
+	PaymentSystemSpecificIDDataEnd                  ID = "99" // (O) 01-99 Payment System specific
+)
+
 // Data Objects for Merchant Information—Language Template (ID "64")
 const (
 	MerchantInformationIDLanguagePreference    ID = "00" // (M) Language Preference
@@ -85,10 +102,20 @@ const (
 	PointOfInitiationMethodDynamic = "12"
 )
 
+const (
+	// TipOrConvenienceIndicatorPrompt ...
+	TipOrConvenienceIndicatorPrompt = "01"
+	// TipOrConvenienceIndicatorFixed ...
+	TipOrConvenienceIndicatorFixed = "02"
+	// TipOrConvenienceIndicatorPercentage ...
+	TipOrConvenienceIndicatorPercentage = "03"
+)
+
 // EMVQR ...
 type EMVQR struct {
 	PayloadFormatIndicator              TLV                                  `json:"Payload Format Indicator"`
 	PointOfInitiationMethod             TLV                                  `json:"Point of Initiation Method"`
+	MerchantAccountPrimitives           []TLV                                `json:"Merchant Account Information (Primitive)"`
 	MerchantAccountInformation          map[ID]MerchantAccountInformationTLV `json:"Merchant Account Information"`
 	MerchantCategoryCode                TLV                                  `json:"Merchant Category Code"`
 	TransactionCurrency                 TLV                                  `json:"Transaction Currency"`
@@ -105,6 +132,10 @@ type EMVQR struct {
 	MerchantInformationLanguageTemplate *MerchantInformationLanguageTemplate `json:"Merchant Information - Language Template"`
 	RFUforEMVCo                         []TLV                                `json:"RFU for EMVCo"`
 	UnreservedTemplates                 map[ID]UnreservedTemplateTLV         `json:"Unreserved Templates"`
+	order                               Order
+	insertionOrder                      []ID
+	layout                              *layout
+	duplicates                          []Duplicate
 }
 
 // MerchantAccountInformationTLV ...
@@ -118,21 +149,40 @@ type MerchantAccountInformationTLV struct {
 type MerchantAccountInformation struct {
 	GloballyUniqueIdentifier TLV   `json:"Globally Unique Identifier"`
 	PaymentNetworkSpecific   []TLV `json:"Payment network specific"`
+	layout                   *layout
 }
 
 // AdditionalDataFieldTemplate ...
 type AdditionalDataFieldTemplate struct {
-	BillNumber                    TLV   `json:"Bill Number"`
-	MobileNumber                  TLV   `json:"Country Code"`
-	StoreLabel                    TLV   `json:"Store Label"`
-	LoyaltyNumber                 TLV   `json:"Loyalty Number"`
-	ReferenceLabel                TLV   `json:"Reference Label"`
-	CustomerLabel                 TLV   `json:"Customer Label"`
-	TerminalLabel                 TLV   `json:"Terminal Label"`
-	PurposeTransaction            TLV   `json:"Purpose of Transaction"`
-	AdditionalConsumerDataRequest TLV   `json:"Additional Consumer Data Request"`
-	RFUforEMVCo                   []TLV `json:"RFU for EMVCo"`
-	PaymentSystemSpecific         []TLV `json:"Payment System specific templates"`
+	BillNumber                    TLV                                `json:"Bill Number"`
+	MobileNumber                  TLV                                `json:"Mobile Number"`
+	StoreLabel                    TLV                                `json:"Store Label"`
+	LoyaltyNumber                 TLV                                `json:"Loyalty Number"`
+	ReferenceLabel                TLV                                `json:"Reference Label"`
+	CustomerLabel                 TLV                                `json:"Customer Label"`
+	TerminalLabel                 TLV                                `json:"Terminal Label"`
+	PurposeTransaction            TLV                                `json:"Purpose of Transaction"`
+	AdditionalConsumerDataRequest TLV                                `json:"Additional Consumer Data Request"`
+	MerchantTaxID                 TLV                                `json:"Merchant Tax ID"`
+	MerchantChannel               TLV                                `json:"Merchant Channel"`
+	RFUforEMVCo                   []TLV                              `json:"RFU for EMVCo"`
+	PaymentSystemSpecific         []PaymentSystemSpecificTemplateTLV `json:"Payment System specific templates"`
+	layout                        *layout
+}
+
+// PaymentSystemSpecificTemplateTLV ...
+type PaymentSystemSpecificTemplateTLV struct {
+	Tag    ID
+	Length string
+	Value  *PaymentSystemSpecificTemplate
+}
+
+// PaymentSystemSpecificTemplate ...
+type PaymentSystemSpecificTemplate struct {
+	GloballyUniqueIdentifier TLV    `json:"Globally Unique Identifier"`
+	PaymentSystemSpecific    []TLV  `json:"Payment System specific"`
+	Raw                      string `json:",omitempty"` // the value, if it is not a template
+	layout                   *layout
 }
 
 // MerchantInformationLanguageTemplate ...
@@ -141,6 +191,7 @@ type MerchantInformationLanguageTemplate struct {
 	MerchantName       TLV   `json:"Merchant Name"`
 	MerchantCity       TLV   `json:"Merchant City"`
 	RFUforEMVCo        []TLV `json:"RFU for EMVCo"`
+	layout             *layout
 }
 
 // UnreservedTemplateTLV ...
@@ -154,6 +205,7 @@ type UnreservedTemplateTLV struct {
 type UnreservedTemplate struct {
 	GloballyUniqueIdentifier TLV   `json:"Globally Unique Identifier"`
 	ContextSpecificData      []TLV `json:"Context Specific Data"`
+	layout                   *layout
 }
 
 // DataType ...
@@ -243,37 +295,43 @@ func (c *EMVQR) RawData() string {
 
 // JSON ...
 func (c *EMVQR) JSON() string {
-	bytes, _ := json.Marshal(c)
+	bytes, _ := json.Marshal(c.ordered())
 	return string(bytes)
 }
 
 func (c *EMVQR) dataWithType(dataType DataType) string {
 	indent := ""
+	o := c.ordered()
 	s := ""
-	s += c.PayloadFormatIndicator.DataWithType(dataType, indent)
-	s += c.PointOfInitiationMethod.DataWithType(dataType, indent)
-	for _, m := range c.MerchantAccountInformation {
+	s += o.PayloadFormatIndicator.DataWithType(dataType, indent)
+	s += o.PointOfInitiationMethod.DataWithType(dataType, indent)
+	for _, m := range o.MerchantAccountPrimitives {
+		s += m.DataWithType(dataType, indent)
+	}
+	for _, id := range o.merchantAccountInformationIDs() {
+		m := o.MerchantAccountInformation[id]
 		s += m.DataWithType(dataType, " ")
 	}
-	s += c.MerchantCategoryCode.DataWithType(dataType, indent)
-	s += c.TransactionCurrency.DataWithType(dataType, indent)
-	s += c.TransactionAmount.DataWithType(dataType, indent)
-	s += c.TipOrConvenienceIndicator.DataWithType(dataType, indent)
-	s += c.ValueOfConvenienceFeeFixed.DataWithType(dataType, indent)
-	s += c.ValueOfConvenienceFeePercentage.DataWithType(dataType, indent)
-	s += c.CountryCode.DataWithType(dataType, indent)
-	s += c.MerchantName.DataWithType(dataType, indent)
-	s += c.MerchantCity.DataWithType(dataType, indent)
-	s += c.PostalCode.DataWithType(dataType, indent)
-	s += c.AdditionalDataFieldTemplate.DataWithType(dataType, " ")
-	s += c.MerchantInformationLanguageTemplate.DataWithType(dataType, " ")
-	for _, r := range c.RFUforEMVCo {
+	s += o.MerchantCategoryCode.DataWithType(dataType, indent)
+	s += o.TransactionCurrency.DataWithType(dataType, indent)
+	s += o.TransactionAmount.DataWithType(dataType, indent)
+	s += o.TipOrConvenienceIndicator.DataWithType(dataType, indent)
+	s += o.ValueOfConvenienceFeeFixed.DataWithType(dataType, indent)
+	s += o.ValueOfConvenienceFeePercentage.DataWithType(dataType, indent)
+	s += o.CountryCode.DataWithType(dataType, indent)
+	s += o.MerchantName.DataWithType(dataType, indent)
+	s += o.MerchantCity.DataWithType(dataType, indent)
+	s += o.PostalCode.DataWithType(dataType, indent)
+	s += o.AdditionalDataFieldTemplate.DataWithType(dataType, " ")
+	s += o.MerchantInformationLanguageTemplate.DataWithType(dataType, " ")
+	for _, r := range o.RFUforEMVCo {
 		s += r.DataWithType(dataType, " ")
 	}
-	for _, u := range c.UnreservedTemplates {
+	for _, id := range o.unreservedTemplateIDs() {
+		u := o.UnreservedTemplates[id]
 		s += u.DataWithType(dataType, " ")
 	}
-	s += c.CRC.DataWithType(dataType, indent)
+	s += o.CRC.DataWithType(dataType, indent)
 	return s
 }
 
@@ -297,6 +355,16 @@ func (c *EMVQR) SetPointOfInitiationMethod(v string) {
 	c.PointOfInitiationMethod = tlv
 }
 
+// AddMerchantAccountPrimitive ...
+func (c *EMVQR) AddMerchantAccountPrimitive(id ID, v string) {
+	tlv := TLV{
+		Tag:    id,
+		Length: l(v),
+		Value:  v,
+	}
+	c.MerchantAccountPrimitives = append(c.MerchantAccountPrimitives, tlv)
+}
+
 // AddMerchantAccountInformation ...
 func (c *EMVQR) AddMerchantAccountInformation(id ID, v *MerchantAccountInformation) {
 	tlv := MerchantAccountInformationTLV{
@@ -308,6 +376,7 @@ func (c *EMVQR) AddMerchantAccountInformation(id ID, v *MerchantAccountInformati
 		c.MerchantAccountInformation = make(map[ID]MerchantAccountInformationTLV)
 	}
 	c.MerchantAccountInformation[id] = tlv
+	c.addInsertionOrder(id)
 }
 
 // SetMerchantCategoryCode ...
@@ -451,6 +520,7 @@ func (c *EMVQR) AddUnreservedTemplates(id ID, v *UnreservedTemplate) {
 		c.UnreservedTemplates = make(map[ID]UnreservedTemplateTLV)
 	}
 	c.UnreservedTemplates[id] = tlv
+	c.addInsertionOrder(id)
 }
 
 // MerchantAccountInformation //
@@ -496,12 +566,9 @@ func (s *MerchantAccountInformation) String() string {
 	if s == nil {
 		return ""
 	}
-	t := ""
-	t += s.GloballyUniqueIdentifier.String()
-	for _, pns := range s.PaymentNetworkSpecific {
-		t += pns.String()
-	}
-	return t
+	objects := tlvObjects(s.GloballyUniqueIdentifier)
+	objects = append(objects, tlvObjects(s.PaymentNetworkSpecific...)...)
+	return s.layout.encode(objects)
 }
 
 // DataWithType ...
@@ -608,6 +675,26 @@ func (s *AdditionalDataFieldTemplate) SetAdditionalConsumerDataRequest(v string)
 	s.AdditionalConsumerDataRequest = tlv
 }
 
+// SetMerchantTaxID ...
+func (s *AdditionalDataFieldTemplate) SetMerchantTaxID(v string) {
+	tlv := TLV{
+		Tag:    AdditionalIDMerchantTaxID,
+		Length: l(v),
+		Value:  v,
+	}
+	s.MerchantTaxID = tlv
+}
+
+// SetMerchantChannel ...
+func (s *AdditionalDataFieldTemplate) SetMerchantChannel(v string) {
+	tlv := TLV{
+		Tag:    AdditionalIDMerchantChannel,
+		Length: l(v),
+		Value:  v,
+	}
+	s.MerchantChannel = tlv
+}
+
 // AddRFUforEMVCo ...
 func (s *AdditionalDataFieldTemplate) AddRFUforEMVCo(id ID, v string) {
 	tlv := TLV{
@@ -618,11 +705,21 @@ func (s *AdditionalDataFieldTemplate) AddRFUforEMVCo(id ID, v string) {
 	s.RFUforEMVCo = append(s.RFUforEMVCo, tlv)
 }
 
-// AddPaymentSystemSpecific ...
+// AddPaymentSystemSpecific adds the Payment System Specific Template id with
+// the encoded value v. A v that is not a template is kept as is.
 func (s *AdditionalDataFieldTemplate) AddPaymentSystemSpecific(id ID, v string) {
-	tlv := TLV{
+	t, err := ParsePaymentSystemSpecificTemplate(v)
+	if err != nil {
+		t = &PaymentSystemSpecificTemplate{Raw: v}
+	}
+	s.AddPaymentSystemSpecificTemplate(id, t)
+}
+
+// AddPaymentSystemSpecificTemplate ...
+func (s *AdditionalDataFieldTemplate) AddPaymentSystemSpecificTemplate(id ID, v *PaymentSystemSpecificTemplate) {
+	tlv := PaymentSystemSpecificTemplateTLV{
 		Tag:    id,
-		Length: l(v),
+		Length: l(v.String()),
 		Value:  v,
 	}
 	s.PaymentSystemSpecific = append(s.PaymentSystemSpecific, tlv)
@@ -633,23 +730,24 @@ func (s *AdditionalDataFieldTemplate) String() string {
 	if s == nil {
 		return ""
 	}
-	t := ""
-	t += s.BillNumber.String()
-	t += s.MobileNumber.String()
-	t += s.StoreLabel.String()
-	t += s.LoyaltyNumber.String()
-	t += s.ReferenceLabel.String()
-	t += s.CustomerLabel.String()
-	t += s.TerminalLabel.String()
-	t += s.PurposeTransaction.String()
-	t += s.AdditionalConsumerDataRequest.String()
-	for _, r := range s.RFUforEMVCo {
-		t += r.String()
-	}
+	objects := tlvObjects(
+		s.BillNumber,
+		s.MobileNumber,
+		s.StoreLabel,
+		s.LoyaltyNumber,
+		s.ReferenceLabel,
+		s.CustomerLabel,
+		s.TerminalLabel,
+		s.PurposeTransaction,
+		s.AdditionalConsumerDataRequest,
+		s.MerchantTaxID,
+		s.MerchantChannel,
+	)
+	objects = append(objects, tlvObjects(s.RFUforEMVCo...)...)
 	for _, p := range s.PaymentSystemSpecific {
-		t += p.String()
+		objects = append(objects, dataObject{id: p.Tag, s: p.String()})
 	}
-	tt := format(IDAdditionalDataFieldTemplate, t)
+	tt := format(IDAdditionalDataFieldTemplate, s.layout.encode(objects))
 	return tt
 }
 
@@ -668,16 +766,84 @@ func (s *AdditionalDataFieldTemplate) DataWithType(dataType DataType, indent str
 	t += s.TerminalLabel.DataWithType(dataType, indent)
 	t += s.PurposeTransaction.DataWithType(dataType, indent)
 	t += s.AdditionalConsumerDataRequest.DataWithType(dataType, indent)
+	t += s.MerchantTaxID.DataWithType(dataType, indent)
+	t += s.MerchantChannel.DataWithType(dataType, indent)
 	for _, r := range s.RFUforEMVCo {
 		t += r.DataWithType(dataType, indent)
 	}
 	for _, p := range s.PaymentSystemSpecific {
-		t += p.DataWithType(dataType, indent)
+		t += indent + p.DataWithType(dataType, indent)
 	}
 	tt := IDAdditionalDataFieldTemplate.String() + " " + ll(s.String()) + "\n" + t
 	return tt
 }
 
+// PaymentSystemSpecificTemplate //
+
+func (s *PaymentSystemSpecificTemplateTLV) String() string {
+	if s == nil {
+		return ""
+	}
+	t := ""
+	t += s.Tag.String() + s.Length + s.Value.String()
+	return t
+}
+
+// DataWithType ..
+func (s *PaymentSystemSpecificTemplateTLV) DataWithType(dataType DataType, indent string) string {
+	if s == nil {
+		return ""
+	}
+	if s.Value != nil && s.Value.Raw != "" {
+		return TLV{Tag: s.Tag, Length: s.Length, Value: s.Value.Raw}.DataWithType(dataType, "")
+	}
+	return s.Tag.String() + " " + s.Length + "\n" + s.Value.DataWithType(dataType, indent)
+}
+
+// SetGloballyUniqueIdentifier ...
+func (s *PaymentSystemSpecificTemplate) SetGloballyUniqueIdentifier(v string) {
+	tlv := TLV{
+		Tag:    PaymentSystemSpecificIDGloballyUniqueIdentifier,
+		Length: l(v),
+		Value:  v,
+	}
+	s.GloballyUniqueIdentifier = tlv
+}
+
+// AddPaymentSystemSpecific ...
+func (s *PaymentSystemSpecificTemplate) AddPaymentSystemSpecific(id ID, v string) {
+	tlv := TLV{
+		Tag:    id,
+		Length: l(v),
+		Value:  v,
+	}
+	s.PaymentSystemSpecific = append(s.PaymentSystemSpecific, tlv)
+}
+
+func (s *PaymentSystemSpecificTemplate) String() string {
+	if s == nil {
+		return ""
+	}
+	if s.Raw != "" {
+		return s.Raw
+	}
+	objects := tlvObjects(s.GloballyUniqueIdentifier)
+	objects = append(objects, tlvObjects(s.PaymentSystemSpecific...)...)
+	return s.layout.encode(objects)
+}
+
+// DataWithType ...
+func (s *PaymentSystemSpecificTemplate) DataWithType(dataType DataType, indent string) string {
+	if s == nil {
+		return ""
+	}
+	t := indent + s.GloballyUniqueIdentifier.DataWithType(dataType, indent)
+	for _, p := range s.PaymentSystemSpecific {
+		t += indent + p.DataWithType(dataType, indent)
+	}
+	return t
+}
+
 // MerchantInformationLanguageTemplate //
 
 // SetLanguagePreference ...
@@ -725,14 +891,9 @@ func (s *MerchantInformationLanguageTemplate) String() string {
 	if s == nil {
 		return ""
 	}
-	t := ""
-	t += s.LanguagePreference.String()
-	t += s.MerchantName.String()
-	t += s.MerchantCity.String()
-	for _, r := range s.RFUforEMVCo {
-		t += r.String()
-	}
-	t = format(IDMerchantInformationLanguageTemplate, t)
+	objects := tlvObjects(s.LanguagePreference, s.MerchantName, s.MerchantCity)
+	objects = append(objects, tlvObjects(s.RFUforEMVCo...)...)
+	t := format(IDMerchantInformationLanguageTemplate, s.layout.encode(objects))
 	return t
 }
 
@@ -796,12 +957,9 @@ func (s *UnreservedTemplate) String() string {
 	if s == nil {
 		return ""
 	}
-	t := ""
-	t += s.GloballyUniqueIdentifier.String()
-	for _, c := range s.ContextSpecificData {
-		t += c.String()
-	}
-	return t
+	objects := tlvObjects(s.GloballyUniqueIdentifier)
+	objects = append(objects, tlvObjects(s.ContextSpecificData...)...)
+	return s.layout.encode(objects)
 }
 
 // DataWithType ...
@@ -818,44 +976,87 @@ func (s *UnreservedTemplate) DataWithType(dataType DataType, indent string) stri
 
 //////////////////////////////////////////////////////////////////////////
 
-// GeneratePayload ...
-func (c *EMVQR) GeneratePayload() string {
-	s := ""
-	s += c.PayloadFormatIndicator.String()
-	s += c.PointOfInitiationMethod.String()
-	for _, m := range c.MerchantAccountInformation {
-		s += m.String()
-	}
-	s += c.MerchantCategoryCode.String()
-	s += c.TransactionCurrency.String()
-	s += c.TransactionAmount.String()
-	s += c.TipOrConvenienceIndicator.String()
-	s += c.ValueOfConvenienceFeeFixed.String()
-	s += c.ValueOfConvenienceFeePercentage.String()
-	s += c.CountryCode.String()
-	s += c.MerchantName.String()
-	s += c.MerchantCity.String()
-	s += c.PostalCode.String()
-	s += c.AdditionalDataFieldTemplate.String()
-	s += c.MerchantInformationLanguageTemplate.String()
-	for _, r := range c.RFUforEMVCo {
-		s += r.String()
-	}
-	for _, u := range c.UnreservedTemplates {
-		s += u.String()
+// GeneratePayload returns the payload of c, with its CRC. A value longer
+// than MaxValueLength, at any nesting level, is an error.
+func (c *EMVQR) GeneratePayload() (string, error) {
+	o := c.ordered()
+	objects := tlvObjects(o.PayloadFormatIndicator, o.PointOfInitiationMethod)
+	objects = append(objects, tlvObjects(o.MerchantAccountPrimitives...)...)
+	for _, id := range o.merchantAccountInformationIDs() {
+		m := o.MerchantAccountInformation[id]
+		objects = append(objects, dataObject{id: id, s: m.String()})
+	}
+	objects = append(objects, tlvObjects(
+		o.MerchantCategoryCode,
+		o.TransactionCurrency,
+		o.TransactionAmount,
+		o.TipOrConvenienceIndicator,
+		o.ValueOfConvenienceFeeFixed,
+		o.ValueOfConvenienceFeePercentage,
+		o.CountryCode,
+		o.MerchantName,
+		o.MerchantCity,
+		o.PostalCode,
+	)...)
+	objects = append(objects,
+		dataObject{id: IDAdditionalDataFieldTemplate, s: o.AdditionalDataFieldTemplate.String()},
+		dataObject{id: IDMerchantInformationLanguageTemplate, s: o.MerchantInformationLanguageTemplate.String()},
+	)
+	objects = append(objects, tlvObjects(o.RFUforEMVCo...)...)
+	for _, id := range o.unreservedTemplateIDs() {
+		u := o.UnreservedTemplates[id]
+		objects = append(objects, dataObject{id: id, s: u.String()})
+	}
+	// a template value holds its data objects, so a nested value too long
+	// makes the top level one too long
+	for _, obj := range objects {
+		if l := utf8.RuneCountInString(obj.s) - 4; l > MaxValueLength {
+			return "", fmt.Errorf("value too long. path: %s, length: %d: %w", obj.id.String(), l, ErrInvalidFormat)
+		}
 	}
+	s := o.layout.encode(objects)
 	s += formatCrc(s)
-	return s
+	return s, nil
 }
 
 // ParseEMVQR ...
 func ParseEMVQR(payload string) (*EMVQR, error) {
+	return ParseEMVQRWithOptions(payload, DecodeOptions{})
+}
+
+// ParseEMVQRWithOptions parses payload into an EMVQR. Unless
+// opts.SkipCRCCheck is set, a CRC (ID "63") found in payload must be the
+// last data object and match the checksum of the payload. A missing CRC is
+// not an error here, so that payload fragments can be parsed; Decode
+// requires it. A repeated ID is handled as opts.Duplicates says. With
+// opts.Lenient, payload is repaired with RepairPayload first.
+func ParseEMVQRWithOptions(payload string, opts DecodeOptions) (*EMVQR, error) {
+	const fnParse = "ParseEMVQR"
+	if opts.Lenient {
+		payload, _ = RepairPayload(payload)
+	}
 	p := NewParser(payload)
 	emvqr := &EMVQR{}
+	l := &layout{}
+	o := newOccurrences(payload, opts)
+	crcOffset := int64(-1)
 	for p.Next() {
+		if crcOffset >= 0 && !opts.SkipCRCCheck {
+			return nil, ErrCRCNotLast
+		}
 		id := p.ID()
 		// length := p.ValueLength()
 		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && id != IDCRC && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
 		switch id {
 		case IDPayloadFormatIndicator:
 			emvqr.SetPayloadFormatIndicator(value)
@@ -882,17 +1083,26 @@ func ParseEMVQR(payload string) (*EMVQR, error) {
 		case IDPostalCode:
 			emvqr.SetPostalCode(value)
 		case IDAdditionalDataFieldTemplate:
-			adft, err := ParseAdditionalDataFieldTemplate(value)
+			adft, err := parseAdditionalDataFieldTemplate(value, opts)
 			if err != nil {
-				return nil, err
+				if opts.Lossless && !errors.Is(err, ErrDuplicateTag) {
+					l.keepLast(id, value)
+					continue
+				}
+				return nil, p.nestedError(id, err)
 			}
 			emvqr.AdditionalDataFieldTemplate = adft
 		case IDCRC:
+			crcOffset = p.current
 			emvqr.SetCRC(value)
 		case IDMerchantInformationLanguageTemplate:
-			t, err := ParseMerchantInformationLanguageTemplate(value)
+			t, err := parseMerchantInformationLanguageTemplate(value, opts)
 			if err != nil {
-				return nil, err
+				if opts.Lossless && !errors.Is(err, ErrDuplicateTag) {
+					l.keepLast(id, value)
+					continue
+				}
+				return nil, p.nestedError(id, err)
 			}
 			emvqr.SetMerchantInformationLanguageTemplate(t)
 		default:
@@ -900,15 +1110,28 @@ func ParseEMVQR(payload string) (*EMVQR, error) {
 				within bool
 				err    error
 			)
-			// Merchant Account Information
-			within, err = id.Between(IDMerchantAccountInformationRangeStart, IDMerchantAccountInformationRangeEnd)
+			// Merchant Account Information, primitive
+			within, err = id.Between(IDMerchantAccountPrimitiveRangeStart, IDMerchantAccountPrimitiveRangeEnd)
+			if err != nil {
+				return nil, p.idError(fnParse, id)
+			}
+			if within {
+				emvqr.AddMerchantAccountPrimitive(id, value)
+				continue
+			}
+			// Merchant Account Information, template
+			within, err = id.Between(IDMerchantAccountTemplateRangeStart, IDMerchantAccountTemplateRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
-				t, err := ParseMerchantAccountInformation(value)
+				t, err := parseMerchantAccountInformation(value, opts)
 				if err != nil {
-					return nil, err
+					if opts.Lossless && !errors.Is(err, ErrDuplicateTag) {
+						l.keepLast(id, value)
+						continue
+					}
+					return nil, p.nestedError(id, err)
 				}
 				emvqr.AddMerchantAccountInformation(id, t)
 				continue
@@ -916,7 +1139,7 @@ func ParseEMVQR(payload string) (*EMVQR, error) {
 			// RFUforEMVCo
 			within, err = id.Between(IDRFUForEMVCoRangeStart, IDRFUForEMVCoRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
 				emvqr.AddRFUforEMVCo(id, value)
@@ -925,12 +1148,16 @@ func ParseEMVQR(payload string) (*EMVQR, error) {
 			// Unreserved Tempaltes
 			within, err = id.Between(IDUnreservedTemplatesRangeStart, IDUnreservedTemplatesRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
-				t, err := ParseUnreservedTemplate(value)
+				t, err := parseUnreservedTemplate(value, opts)
 				if err != nil {
-					return nil, err
+					if opts.Lossless && !errors.Is(err, ErrDuplicateTag) {
+						l.keepLast(id, value)
+						continue
+					}
+					return nil, p.nestedError(id, err)
 				}
 				emvqr.AddUnreservedTemplates(id, t)
 				continue
@@ -940,54 +1167,53 @@ func ParseEMVQR(payload string) (*EMVQR, error) {
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
-	return emvqr, nil
-}
-
-// Validate ...
-func (c *EMVQR) Validate() error {
-	// check mandatory
-	if c.PayloadFormatIndicator.Value == "" {
-		return errors.New("PayloadFormatIndicator is mandatory")
-	}
-	if len(c.MerchantAccountInformation) <= 0 {
-		return errors.New("MerchantAccountInformation is mandatory")
-	}
-	if c.MerchantCategoryCode.Value == "" {
-		return errors.New("MerchantCategoryCode is mandatory")
-	}
-	if c.TransactionCurrency.Value == "" {
-		return errors.New("TransactionCurrency is mandatory")
-	}
-	if c.CountryCode.Value == "" {
-		return errors.New("CountryCode is mandatory")
+	if opts.Lossless {
+		emvqr.layout = l
 	}
-	if c.MerchantName.Value == "" {
-		return errors.New("MerchantName is mandatory")
-	}
-	if c.MerchantCity.Value == "" {
-		return errors.New("MerchantCity is mandatory")
-	}
-	// check validate
-	if c.PointOfInitiationMethod.Value != "" {
-		if c.PointOfInitiationMethod.Value != PointOfInitiationMethodStatic && c.PointOfInitiationMethod.Value != PointOfInitiationMethodDynamic {
-			return fmt.Errorf("PointOfInitiationMethod should be \"11\" or \"12\", PointOfInitiationMethod: %s", c)
+	if o.policy != DuplicateReject {
+		nodes, err := parseNodes("", payload)
+		if err != nil {
+			return nil, err
 		}
+		emvqr.duplicates = findDuplicates("", nodes, o.policy)
 	}
-	if c.MerchantInformationLanguageTemplate != nil {
-		if err := c.MerchantInformationLanguageTemplate.Validate(); err != nil {
-			return err
+	if crcOffset >= 0 && !opts.SkipCRCCheck {
+		if err := verifyCRC(p.source, crcOffset, emvqr.CRC.Value); err != nil {
+			return nil, err
 		}
 	}
-	return nil
+	return emvqr, nil
+}
+
+// Validate ...
+func (c *EMVQR) Validate() error {
+	return c.ValidateAll().firstError()
 }
 
 // ParseAdditionalDataFieldTemplate ...
 func ParseAdditionalDataFieldTemplate(payload string) (*AdditionalDataFieldTemplate, error) {
+	return parseAdditionalDataFieldTemplate(payload, DecodeOptions{})
+}
+
+func parseAdditionalDataFieldTemplate(payload string, opts DecodeOptions) (*AdditionalDataFieldTemplate, error) {
+	const fnParse = "ParseAdditionalDataFieldTemplate"
 	p := NewParser(payload)
+	l := &layout{}
+	o := newOccurrences(payload, opts)
 	additionalDataFieldTemplate := &AdditionalDataFieldTemplate{}
 	for p.Next() {
 		id := p.ID()
 		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
 		switch id {
 		case AdditionalIDBillNumber:
 			additionalDataFieldTemplate.SetBillNumber(value)
@@ -1007,6 +1233,10 @@ func ParseAdditionalDataFieldTemplate(payload string) (*AdditionalDataFieldTempl
 			additionalDataFieldTemplate.SetPurposeTransaction(value)
 		case AdditionalIDAdditionalConsumerDataRequest:
 			additionalDataFieldTemplate.SetAdditionalConsumerDataRequest(value)
+		case AdditionalIDMerchantTaxID:
+			additionalDataFieldTemplate.SetMerchantTaxID(value)
+		case AdditionalIDMerchantChannel:
+			additionalDataFieldTemplate.SetMerchantChannel(value)
 		default:
 			var (
 				within bool
@@ -1015,16 +1245,28 @@ func ParseAdditionalDataFieldTemplate(payload string) (*AdditionalDataFieldTempl
 			// Payment System Specific
 			within, err = id.Between(AdditionalIDPaymentSystemSpecificTemplatesRangeStart, AdditionalIDPaymentSystemSpecificTemplatesRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
-				additionalDataFieldTemplate.AddPaymentSystemSpecific(id, value)
+				t, err := parsePaymentSystemSpecificTemplate(value, opts)
+				if err != nil {
+					switch {
+					case errors.Is(err, ErrDuplicateTag):
+						return nil, p.nestedError(id, err)
+					case opts.Lossless:
+						l.keepLast(id, value)
+					default:
+						additionalDataFieldTemplate.AddPaymentSystemSpecific(id, value)
+					}
+					continue
+				}
+				additionalDataFieldTemplate.AddPaymentSystemSpecificTemplate(id, t)
 				continue
 			}
 			// RFU for EMVCo
 			within, err = id.Between(AdditionalIDRFUforEMVCoRangeStart, AdditionalIDRFUforEMVCoRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
 				additionalDataFieldTemplate.AddRFUforEMVCo(id, value)
@@ -1035,16 +1277,83 @@ func ParseAdditionalDataFieldTemplate(payload string) (*AdditionalDataFieldTempl
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
+	if opts.Lossless {
+		additionalDataFieldTemplate.layout = l
+	}
 	return additionalDataFieldTemplate, nil
 }
 
+// ParsePaymentSystemSpecificTemplate ...
+func ParsePaymentSystemSpecificTemplate(value string) (*PaymentSystemSpecificTemplate, error) {
+	return parsePaymentSystemSpecificTemplate(value, DecodeOptions{})
+}
+
+func parsePaymentSystemSpecificTemplate(value string, opts DecodeOptions) (*PaymentSystemSpecificTemplate, error) {
+	const fnParse = "ParsePaymentSystemSpecificTemplate"
+	p := NewParser(value)
+	l := &layout{}
+	o := newOccurrences(value, opts)
+	paymentSystemSpecificTemplate := &PaymentSystemSpecificTemplate{}
+	for p.Next() {
+		id := p.ID()
+		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
+		switch id {
+		case PaymentSystemSpecificIDGloballyUniqueIdentifier:
+			paymentSystemSpecificTemplate.SetGloballyUniqueIdentifier(value)
+		default:
+			within, err := id.Between(PaymentSystemSpecificIDDataStart, PaymentSystemSpecificIDDataEnd)
+			if err != nil {
+				return nil, p.idError(fnParse, id)
+			}
+			if within {
+				paymentSystemSpecificTemplate.AddPaymentSystemSpecific(id, value)
+				continue
+			}
+		}
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if opts.Lossless {
+		paymentSystemSpecificTemplate.layout = l
+	}
+	return paymentSystemSpecificTemplate, nil
+}
+
 // ParseMerchantAccountInformation ...
 func ParseMerchantAccountInformation(value string) (*MerchantAccountInformation, error) {
+	return parseMerchantAccountInformation(value, DecodeOptions{})
+}
+
+func parseMerchantAccountInformation(value string, opts DecodeOptions) (*MerchantAccountInformation, error) {
+	const fnParse = "ParseMerchantAccountInformation"
 	p := NewParser(value)
+	l := &layout{}
+	o := newOccurrences(value, opts)
 	merchantAccountInformation := &MerchantAccountInformation{}
 	for p.Next() {
 		id := p.ID()
 		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
 		switch id {
 		case MerchantAccountInformationIDGloballyUniqueIdentifier:
 			merchantAccountInformation.SetGloballyUniqueIdentifier(value)
@@ -1055,7 +1364,7 @@ func ParseMerchantAccountInformation(value string) (*MerchantAccountInformation,
 			)
 			within, err = id.Between(MerchantAccountInformationIDPaymentNetworkSpecificStart, MerchantAccountInformationIDPaymentNetworkSpecificEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
 				merchantAccountInformation.AddPaymentNetworkSpecific(id, value)
@@ -1066,16 +1375,36 @@ func ParseMerchantAccountInformation(value string) (*MerchantAccountInformation,
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
+	if opts.Lossless {
+		merchantAccountInformation.layout = l
+	}
 	return merchantAccountInformation, nil
 }
 
 // ParseMerchantInformationLanguageTemplate ...
 func ParseMerchantInformationLanguageTemplate(value string) (*MerchantInformationLanguageTemplate, error) {
+	return parseMerchantInformationLanguageTemplate(value, DecodeOptions{})
+}
+
+func parseMerchantInformationLanguageTemplate(value string, opts DecodeOptions) (*MerchantInformationLanguageTemplate, error) {
+	const fnParse = "ParseMerchantInformationLanguageTemplate"
 	p := NewParser(value)
+	l := &layout{}
+	o := newOccurrences(value, opts)
 	merchantInformationLanguageTemplate := &MerchantInformationLanguageTemplate{}
 	for p.Next() {
 		id := p.ID()
 		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
 		switch id {
 		case MerchantInformationIDLanguagePreference:
 			merchantInformationLanguageTemplate.SetLanguagePreference(value)
@@ -1091,7 +1420,7 @@ func ParseMerchantInformationLanguageTemplate(value string) (*MerchantInformatio
 			// RFU for EMVCo
 			within, err = id.Between(MerchantInformationIDRFUforEMVCoRangeStart, MerchantInformationIDRFUforEMVCoRangeEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
 				merchantInformationLanguageTemplate.AddRFUForEMVCo(id, value)
@@ -1102,16 +1431,36 @@ func ParseMerchantInformationLanguageTemplate(value string) (*MerchantInformatio
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
+	if opts.Lossless {
+		merchantInformationLanguageTemplate.layout = l
+	}
 	return merchantInformationLanguageTemplate, nil
 }
 
 // ParseUnreservedTemplate ...
 func ParseUnreservedTemplate(value string) (*UnreservedTemplate, error) {
+	return parseUnreservedTemplate(value, DecodeOptions{})
+}
+
+func parseUnreservedTemplate(value string, opts DecodeOptions) (*UnreservedTemplate, error) {
+	const fnParse = "ParseUnreservedTemplate"
 	p := NewParser(value)
+	l := &layout{}
+	o := newOccurrences(value, opts)
 	unreservedTemplate := &UnreservedTemplate{}
 	for p.Next() {
 		id := p.ID()
 		value := p.Value()
+		decode, err := o.check(p, fnParse, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Lossless && l.keep(id, value, decode) {
+			continue
+		}
+		if !decode {
+			continue
+		}
 		switch id {
 		case UnreservedTemplateIDGloballyUniqueIdentifier:
 			unreservedTemplate.SetGloballyUniqueIdentifier(value)
@@ -1122,7 +1471,7 @@ func ParseUnreservedTemplate(value string) (*UnreservedTemplate, error) {
 			)
 			within, err = id.Between(UnreservedTemplateIDContextSpecificDataStart, UnreservedTemplateIDContextSpecificDataEnd)
 			if err != nil {
-				return nil, err
+				return nil, p.idError(fnParse, id)
 			}
 			if within {
 				unreservedTemplate.AddContextSpecificData(id, value)
@@ -1133,19 +1482,45 @@ func ParseUnreservedTemplate(value string) (*UnreservedTemplate, error) {
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
+	if opts.Lossless {
+		unreservedTemplate.layout = l
+	}
 	return unreservedTemplate, nil
 }
 
 // Validate ...
 func (s *MerchantInformationLanguageTemplate) Validate() error {
-	// check mandatory
-	if s.LanguagePreference.Value == "" {
-		return errors.New("LanguagePreference is mandatory")
-	}
-	if s.MerchantName.Value == "" {
-		return errors.New("MerchantName is mandatory")
-	}
-	return nil
+	r := &ValidationReport{}
+	s.validate(r)
+	return r.firstError()
+}
+
+// Validate ...
+func (s *MerchantAccountInformation) Validate() error {
+	r := &ValidationReport{}
+	s.validate(r, "")
+	return r.firstError()
+}
+
+// Validate ...
+func (s *AdditionalDataFieldTemplate) Validate() error {
+	r := &ValidationReport{}
+	s.validate(r)
+	return r.firstError()
+}
+
+// Validate ...
+func (s *PaymentSystemSpecificTemplate) Validate() error {
+	r := &ValidationReport{}
+	s.validate(r, "")
+	return r.firstError()
+}
+
+// Validate ...
+func (s *UnreservedTemplate) Validate() error {
+	r := &ValidationReport{}
+	s.validate(r, "")
+	return r.firstError()
 }
 
 func format(id ID, value string) string {
@@ -1154,11 +1529,7 @@ func format(id ID, value string) string {
 }
 
 func formatCrc(value string) string {
-	table := crc16.MakeTable(crc16.CRC16_CCITT_FALSE)
-	crcValue := crc16.Checksum([]byte(value+IDCRC.String()+"04"), table)
-	crcValueString := strconv.FormatUint(uint64(crcValue), 16)
-	s := "0000" + strings.ToUpper(crcValueString)
-	return format(IDCRC, s[len(s)-4:])
+	return format(IDCRC, checksum(value+IDCRC.String()+"04"))
 }
 
 func l(v string) string {
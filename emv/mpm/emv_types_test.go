@@ -1,7 +1,9 @@
 package mpm
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -527,11 +529,11 @@ func TestEMVQR_GeneratePayload(t *testing.T) {
 				RFUforEMVCo:                         tt.fields.RFUforEMVCo,
 				UnreservedTemplates:                 tt.fields.UnreservedTemplates,
 			}
-			got := c.GeneratePayload()
-			// if (err != nil) != tt.wantErr {
-			// 	t.Errorf("EMVQR.GeneratePayload() error = %v, wantErr %v", err, tt.wantErr)
-			// 	return
-			// }
+			got, err := c.GeneratePayload()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EMVQR.GeneratePayload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if got != tt.want {
 				t.Errorf("EMVQR.GeneratePayload() = %v, want %v", got, tt.want)
 			}
@@ -539,6 +541,43 @@ func TestEMVQR_GeneratePayload(t *testing.T) {
 	}
 }
 
+func TestEMVQR_GeneratePayload_TooLong(t *testing.T) {
+	long := strings.Repeat("A", MaxValueLength+1)
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		path   string
+	}{
+		{
+			name:   "primitive",
+			modify: func(c *EMVQR) { c.SetMerchantName(long) },
+			path:   "59",
+		},
+		{
+			name: "nested",
+			modify: func(c *EMVQR) {
+				a := new(AdditionalDataFieldTemplate)
+				a.SetReferenceLabel(long)
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			path: "62",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got, err := c.GeneratePayload()
+			if !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), "path: "+tt.path+",") {
+				t.Errorf("EMVQR.GeneratePayload() error = %v, want ErrInvalidFormat at %v", err, tt.path)
+			}
+			if got != "" {
+				t.Errorf("EMVQR.GeneratePayload() = %v, want empty", got)
+			}
+		})
+	}
+}
+
 func Test_ParseEMVQR(t *testing.T) {
 	type args struct {
 		payload string
@@ -695,13 +734,29 @@ func Test_ParseEMVQR(t *testing.T) {
 		{
 			name: "parse crc",
 			args: args{
-				payload: "6304A13A",
+				payload: "63046007",
 			},
 			want: &EMVQR{
-				CRC: TLV{IDCRC, "04", "A13A"},
+				CRC: TLV{IDCRC, "04", "6007"},
 			},
 			wantErr: false,
 		},
+		{
+			name: "parse crc mismatch",
+			args: args{
+				payload: "6304A13A",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "parse crc not last",
+			args: args{
+				payload: "63046007000201",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "parse additional data field template",
 			args: args{
@@ -750,12 +805,12 @@ func Test_ParseEMVQR(t *testing.T) {
 		{
 			name: "parse merchant account information",
 			args: args{
-				payload: "02160004hoge0104abcd",
+				payload: "27160004hoge0104abcd",
 			},
 			want: &EMVQR{
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{
-						Tag:    "02",
+					ID("27"): MerchantAccountInformationTLV{
+						Tag:    "27",
 						Length: "16",
 						Value: &MerchantAccountInformation{
 							GloballyUniqueIdentifier: TLV{
@@ -773,13 +828,14 @@ func Test_ParseEMVQR(t *testing.T) {
 						},
 					},
 				},
+				insertionOrder: []ID{"27"},
 			},
 			wantErr: false,
 		},
 		{
 			name: "parse failed merchant account information",
 			args: args{
-				payload: "02140004hoge0104", // not enough length
+				payload: "27140004hoge0104", // not enough length
 			},
 			want:    nil,
 			wantErr: true,
@@ -787,12 +843,12 @@ func Test_ParseEMVQR(t *testing.T) {
 		{
 			name: "parse multiple merchant account information",
 			args: args{
-				payload: "02160004hoge0104abcd26160004fuga0204efgh",
+				payload: "27160004hoge0104abcd26160004fuga0204efgh",
 			},
 			want: &EMVQR{
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{
-						Tag:    "02",
+					ID("27"): MerchantAccountInformationTLV{
+						Tag:    "27",
 						Length: "16",
 						Value: &MerchantAccountInformation{
 							GloballyUniqueIdentifier: TLV{
@@ -828,6 +884,7 @@ func Test_ParseEMVQR(t *testing.T) {
 						},
 					},
 				},
+				insertionOrder: []ID{"27", "26"},
 			},
 			wantErr: false,
 		},
@@ -894,6 +951,7 @@ func Test_ParseEMVQR(t *testing.T) {
 						},
 					},
 				},
+				insertionOrder: []ID{"80"},
 			},
 			wantErr: false,
 		},
@@ -928,6 +986,7 @@ func Test_ParseEMVQR(t *testing.T) {
 						},
 					},
 				},
+				insertionOrder: []ID{"80"},
 			},
 			wantErr: false,
 		},
@@ -981,7 +1040,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1036,7 +1105,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 			},
 			wantErr: true,
@@ -1050,7 +1129,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1069,7 +1158,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1093,7 +1192,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1122,7 +1231,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1156,7 +1275,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1195,7 +1324,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1235,7 +1374,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1274,7 +1423,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1314,7 +1473,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1354,7 +1523,17 @@ func TestEMVQR_Validate(t *testing.T) {
 					Value:  "01",
 				},
 				MerchantAccountInformation: map[ID]MerchantAccountInformationTLV{
-					ID("02"): MerchantAccountInformationTLV{},
+					ID("26"): MerchantAccountInformationTLV{
+						Tag:    "26",
+						Length: "08",
+						Value: &MerchantAccountInformation{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    MerchantAccountInformationIDGloballyUniqueIdentifier,
+								Length: "04",
+								Value:  "hoge",
+							},
+						},
+					},
 				},
 				MerchantCategoryCode: TLV{
 					Tag:    IDMerchantCategoryCode,
@@ -1627,25 +1806,82 @@ func Test_ParseAdditionalDataFieldTemplate(t *testing.T) {
 				payload: "50160004hoge0104abcd",
 			},
 			want: &AdditionalDataFieldTemplate{
-				PaymentSystemSpecific: []TLV{
-					TLV{
+				PaymentSystemSpecific: []PaymentSystemSpecificTemplateTLV{
+					PaymentSystemSpecificTemplateTLV{
 						Tag:    "50",
 						Length: "16",
-						Value:  "0004hoge0104abcd",
+						Value: &PaymentSystemSpecificTemplate{
+							GloballyUniqueIdentifier: TLV{
+								Tag:    "00",
+								Length: "04",
+								Value:  "hoge",
+							},
+							PaymentSystemSpecific: []TLV{
+								TLV{
+									Tag:    "01",
+									Length: "04",
+									Value:  "abcd",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "parse opaque payment system specific",
+			args: args{
+				payload: "5004hoge",
+			},
+			want: &AdditionalDataFieldTemplate{
+				PaymentSystemSpecific: []PaymentSystemSpecificTemplateTLV{
+					PaymentSystemSpecificTemplateTLV{
+						Tag:    "50",
+						Length: "04",
+						Value:  &PaymentSystemSpecificTemplate{Raw: "hoge"},
 					},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "parse merchant tax id",
+			args: args{
+				payload: "1010TAX1234567",
+			},
+			want: &AdditionalDataFieldTemplate{
+				MerchantTaxID: TLV{
+					Tag:    AdditionalIDMerchantTaxID,
+					Length: "10",
+					Value:  "TAX1234567",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "parse merchant channel",
+			args: args{
+				payload: "1103000",
+			},
+			want: &AdditionalDataFieldTemplate{
+				MerchantChannel: TLV{
+					Tag:    AdditionalIDMerchantChannel,
+					Length: "03",
+					Value:  "000",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "parse RFU for EMVCo",
 			args: args{
-				payload: "1004abcd",
+				payload: "1204abcd",
 			},
 			want: &AdditionalDataFieldTemplate{
 				RFUforEMVCo: []TLV{
 					TLV{
-						Tag:    "10",
+						Tag:    "12",
 						Length: "04",
 						Value:  "abcd",
 					},
@@ -1656,12 +1892,12 @@ func Test_ParseAdditionalDataFieldTemplate(t *testing.T) {
 		{
 			name: "parse multiple RFU for EMVCo",
 			args: args{
-				payload: "1004abcd4904efgh",
+				payload: "1204abcd4904efgh",
 			},
 			want: &AdditionalDataFieldTemplate{
 				RFUforEMVCo: []TLV{
 					TLV{
-						Tag:    "10",
+						Tag:    "12",
 						Length: "04",
 						Value:  "abcd",
 					},
@@ -1795,3 +2031,37 @@ func Test_ParseMerchantInformationLanguageTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestAdditionalDataFieldTemplate_AddPaymentSystemSpecific(t *testing.T) {
+	a := new(AdditionalDataFieldTemplate)
+	a.AddPaymentSystemSpecific("50", "0004hoge0104abcd")
+	a.AddPaymentSystemSpecific("51", "hoge")
+	a.AddPaymentSystemSpecific("52", "AB01X")
+	if got := a.PaymentSystemSpecific[0].Value.GloballyUniqueIdentifier.Value; got != "hoge" {
+		t.Errorf("GloballyUniqueIdentifier = %v, want hoge", got)
+	}
+	value := "50160004hoge0104abcd" + "5104hoge" + "5205AB01X"
+	if got, want := a.String(), "6237"+value; got != want {
+		t.Errorf("AdditionalDataFieldTemplate.String() = %v, want %v", got, want)
+	}
+	want, err := ParseAdditionalDataFieldTemplate(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("AddPaymentSystemSpecific() = %+v, want %+v", a, want)
+	}
+	c := validEMVQR()
+	c.SetAdditionalDataFieldTemplate(a)
+	payload, err := c.GeneratePayload()
+	if err != nil {
+		t.Fatalf("EMVQR.GeneratePayload() error = %v", err)
+	}
+	got, err := Decode(payload)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if s := got.AdditionalDataFieldTemplate.String(); s != a.String() {
+		t.Errorf("AdditionalDataFieldTemplate.String() = %v, want %v", s, a.String())
+	}
+}
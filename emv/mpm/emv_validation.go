@@ -0,0 +1,606 @@
+package mpm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Severity ...
+type Severity string
+
+// const ...
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule names the validation rule a Violation broke.
+type Rule string
+
+// const ...
+const (
+	RuleMandatory        Rule = "mandatory"
+	RuleLength           Rule = "length"
+	RuleCharacterSet     Rule = "character-set"
+	RuleValue            Rule = "value"
+	RuleIDRange          Rule = "id-range"
+	RuleLengthIndicator  Rule = "length-indicator"
+	RuleCountryCurrency  Rule = "country-currency"
+	RuleMerchantCategory Rule = "merchant-category"
+	RuleConditional      Rule = "conditional"
+	RuleDuplicate        Rule = "duplicate"
+)
+
+// Violation is a single validation failure. Path is the tag path of the
+// data object, e.g. "62.05" for the Reference Label.
+type Violation struct {
+	Path     string   `json:"path"`
+	Field    string   `json:"field"`
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	Value    string   `json:"value,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (v Violation) Error() string {
+	return v.Message
+}
+
+// Unwrap returns the error kind of v: *ErrMandatoryMissing, ErrUnknownTag,
+// ErrDuplicateTag or ErrInvalidFormat.
+func (v Violation) Unwrap() error {
+	switch v.Rule {
+	case RuleMandatory:
+		return &ErrMandatoryMissing{Tag: v.Path}
+	case RuleIDRange:
+		return ErrUnknownTag
+	case RuleDuplicate:
+		return ErrDuplicateTag
+	}
+	return ErrInvalidFormat
+}
+
+// ValidationReport lists every violation found by ValidateAll.
+type ValidationReport struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (r *ValidationReport) Error() string {
+	messages := make([]string, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		messages = append(messages, v.Path+": "+v.Message)
+	}
+	return strconv.Itoa(len(r.Violations)) + " violation(s): " + strings.Join(messages, "; ")
+}
+
+// Is reports whether any violation of SeverityError matches target.
+func (r *ValidationReport) Is(target error) bool {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError && errors.Is(v, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first violation of SeverityError that matches target.
+func (r *ValidationReport) As(target interface{}) bool {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError && errors.As(v, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrors reports whether the report contains a violation of SeverityError.
+func (r *ValidationReport) HasErrors() bool {
+	return r.firstError() != nil
+}
+
+// Err returns r if it contains a violation of SeverityError, otherwise nil.
+func (r *ValidationReport) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return r
+}
+
+func (r *ValidationReport) firstError() error {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError {
+			return v
+		}
+	}
+	return nil
+}
+
+func (r *ValidationReport) add(path, field string, rule Rule, value string, format string, a ...interface{}) {
+	r.Violations = append(r.Violations, Violation{
+		Path:     path,
+		Field:    field,
+		Rule:     rule,
+		Severity: SeverityError,
+		Value:    value,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+func (r *ValidationReport) warn(path, field string, rule Rule, value string, format string, a ...interface{}) {
+	r.add(path, field, rule, value, format, a...)
+	r.Violations[len(r.Violations)-1].Severity = SeverityWarning
+}
+
+func (r *ValidationReport) mandatory(path, field, value string) {
+	if value == "" {
+		r.add(path, field, RuleMandatory, "", "%s is mandatory", field)
+	}
+}
+
+// format checks the character set and length of tlv. An empty value is not
+// checked; mandatory data objects are checked separately.
+func (r *ValidationReport) format(path, field string, tlv TLV, format Format, min, max int) {
+	if tlv.Value == "" {
+		return
+	}
+	n := utf8.RuneCountInString(tlv.Value)
+	if min == max && n != min {
+		r.add(path, field, RuleLength, tlv.Value, "%s should be %d characters, %s: %s", field, min, field, tlv.Value)
+	} else if n < min || n > max {
+		r.add(path, field, RuleLength, tlv.Value, "%s should be %d to %d characters, %s: %s", field, min, max, field, tlv.Value)
+	}
+	if !format.Match(tlv.Value) {
+		r.add(path, field, RuleCharacterSet, tlv.Value, "%s should be %s, %s: %s", field, format, field, tlv.Value)
+	}
+	if tlv.Length != l(tlv.Value) {
+		r.add(path, field, RuleLengthIndicator, tlv.Length, "%s length should be %s, length: %s", field, l(tlv.Value), tlv.Length)
+	}
+	r.consumerInput(path, field, tlv)
+}
+
+// consumerInput reports ConsumerInput in a data object that does not allow
+// it. Only IDs "62.01" to "62.08" do.
+func (r *ValidationReport) consumerInput(path, field string, tlv TLV) {
+	if tlv.Value == ConsumerInput && !consumerInputAllowed(path) {
+		r.add(path, field, RuleValue, tlv.Value, "%s should not prompt for consumer input, %s: %s", field, field, tlv.Value)
+	}
+}
+
+// guid warns about a Globally Unique Identifier that is neither an AID, a
+// UUID nor a reverse domain name.
+func (r *ValidationReport) guid(path string, tlv TLV) {
+	if tlv.Value == "" {
+		return
+	}
+	if _, err := ParseGUID(tlv.Value); err != nil {
+		r.warn(path, "GloballyUniqueIdentifier", RuleValue, tlv.Value, "GloballyUniqueIdentifier should be an AID, a UUID or a reverse domain name, GloballyUniqueIdentifier: %s", tlv.Value)
+	}
+}
+
+// templateLength checks that a template value fits in a two digit length
+// field.
+func (r *ValidationReport) templateLength(path, field string, value string) {
+	if n := utf8.RuneCountInString(value); n > MaxValueLength {
+		r.add(path, field, RuleLength, value, "%s should be at most %d characters, length: %d", field, MaxValueLength, n)
+	}
+}
+
+func (r *ValidationReport) amount(path, field string, tlv TLV) {
+	r.format(path, field, tlv, FormatAlphanumericSpecial, 1, 13)
+	if tlv.Value != "" && !amountPattern.MatchString(tlv.Value) {
+		r.add(path, field, RuleValue, tlv.Value, "%s should be digits with an optional \".\" decimal mark, %s: %s", field, field, tlv.Value)
+	}
+}
+
+func (r *ValidationReport) percentage(path, field string, tlv TLV) {
+	r.format(path, field, tlv, FormatAlphanumericSpecial, 1, 5)
+	if tlv.Value != "" && (!percentagePattern.MatchString(tlv.Value) || strings.Trim(tlv.Value, "0.") == "") {
+		r.add(path, field, RuleValue, tlv.Value, "%s should be between \"00.01\" and \"99.99\", %s: %s", field, field, tlv.Value)
+	}
+}
+
+// convenience checks the Tip or Convenience Indicator and the fee data
+// objects it requires: ID "56" only with "02", ID "57" only with "03".
+func (r *ValidationReport) convenience(c *EMVQR) {
+	v := c.TipOrConvenienceIndicator.Value
+	switch v {
+	case "", TipOrConvenienceIndicatorPrompt, TipOrConvenienceIndicatorFixed, TipOrConvenienceIndicatorPercentage:
+	default:
+		r.add(IDTipOrConvenienceIndicator.String(), "TipOrConvenienceIndicator", RuleValue, v, "TipOrConvenienceIndicator should be \"01\", \"02\" or \"03\", TipOrConvenienceIndicator: %s", v)
+	}
+	fees := []struct {
+		id        ID
+		name      string
+		tlv       TLV
+		indicator string
+	}{
+		{IDValueOfConvenienceFeeFixed, "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed, TipOrConvenienceIndicatorFixed},
+		{IDValueOfConvenienceFeePercentage, "ValueOfConvenienceFeePercentage", c.ValueOfConvenienceFeePercentage, TipOrConvenienceIndicatorPercentage},
+	}
+	for _, f := range fees {
+		if v == f.indicator {
+			r.mandatory(f.id.String(), f.name, f.tlv.Value)
+		} else if f.tlv.Value != "" {
+			r.add(f.id.String(), f.name, RuleConditional, f.tlv.Value, "%s should be present only if TipOrConvenienceIndicator is \"%s\", TipOrConvenienceIndicator: %s", f.name, f.indicator, v)
+		}
+	}
+}
+
+// currency checks the Transaction Currency against ISO 4217 and the
+// decimals of the amounts in it. An unknown currency is a warning.
+func (r *ValidationReport) currency(c *EMVQR) {
+	code := c.TransactionCurrency.Value
+	if len(code) != 3 || !FormatNumeric.Match(code) {
+		return
+	}
+	cur, ok := LookupCurrency(code)
+	if !ok {
+		r.warn(IDTransactionCurrency.String(), "TransactionCurrency", RuleValue, code, "TransactionCurrency should be an ISO 4217 numeric code, TransactionCurrency: %s", code)
+		return
+	}
+	amounts := []struct {
+		id   ID
+		name string
+		tlv  TLV
+	}{
+		{IDTransactionAmount, "TransactionAmount", c.TransactionAmount},
+		{IDValueOfConvenienceFeeFixed, "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed},
+	}
+	for _, a := range amounts {
+		v := a.tlv.Value
+		if v != "" && amountPattern.MatchString(v) && decimals(v) > cur.MinorUnits {
+			r.add(a.id.String(), a.name, RuleValue, v, "%s should have at most %d decimals for %s, %s: %s", a.name, cur.MinorUnits, cur.Alpha, a.name, v)
+		}
+	}
+}
+
+// countryCurrency checks that the Transaction Currency is in usual use in
+// the country of the Country Code.
+func (r *ValidationReport) countryCurrency(c *EMVQR) {
+	country, ok := c.Country()
+	if !ok {
+		return
+	}
+	currency, ok := c.Currency()
+	if !ok || country.UsesCurrency(currency.Code) {
+		return
+	}
+	add := r.add
+	if globalCurrencies[currency.Code] {
+		add = r.warn
+	}
+	add(IDTransactionCurrency.String(), "TransactionCurrency", RuleCountryCurrency, currency.Code, "TransactionCurrency %s is not in usual use in %s, TransactionCurrency: %s", currency.Alpha, country.Name, currency.Code)
+}
+
+// merchantCategory checks the Merchant Category Code against the ISO 18245
+// table and the blocked codes of opts.
+func (r *ValidationReport) merchantCategory(c *EMVQR, opts ValidationOptions) {
+	code := c.MerchantCategoryCode.Value
+	if len(code) != 4 || !FormatNumeric.Match(code) {
+		return
+	}
+	for _, b := range opts.BlockedMCCs {
+		if b == code {
+			r.add(IDMerchantCategoryCode.String(), "MerchantCategoryCode", RuleMerchantCategory, code, "MerchantCategoryCode is blocked, MerchantCategoryCode: %s", code)
+			return
+		}
+	}
+	if !opts.UnknownMCC {
+		return
+	}
+	if _, ok := LookupMerchantCategory(code); ok {
+		return
+	}
+	for _, a := range opts.AllowedMCCRanges {
+		if a.Contains(code) {
+			return
+		}
+	}
+	r.add(IDMerchantCategoryCode.String(), "MerchantCategoryCode", RuleMerchantCategory, code, "MerchantCategoryCode should be an ISO 18245 code, MerchantCategoryCode: %s", code)
+}
+
+func (r *ValidationReport) idRange(path, field string, id ID, start ID, end ID) bool {
+	within, err := id.Between(start, end)
+	if err != nil || !within || len(id) != IDWordCount {
+		r.add(path, field, RuleIDRange, id.String(), "%s ID should be between %s and %s, ID: %s", field, start, end, id)
+		return false
+	}
+	return true
+}
+
+func tagPath(parent string, id ID) string {
+	if parent == "" {
+		return id.String()
+	}
+	return parent + "." + id.String()
+}
+
+// ValidationOptions enables the optional validation rules.
+type ValidationOptions struct {
+	// CountryCurrency checks that the Transaction Currency is in usual use
+	// in the country of the Country Code. US Dollar and Euro elsewhere are
+	// reported as warnings, any other currency as an error.
+	CountryCurrency bool
+	// UnknownMCC rejects a Merchant Category Code that is not in the ISO
+	// 18245 table, unless it is in one of AllowedMCCRanges.
+	UnknownMCC bool
+	// AllowedMCCRanges are the reserved or private ranges accepted by
+	// UnknownMCC.
+	AllowedMCCRanges []MCCRange
+	// BlockedMCCs are rejected Merchant Category Codes.
+	BlockedMCCs []string
+}
+
+// ValidateAll validates c and returns every violation it finds.
+func (c *EMVQR) ValidateAll() *ValidationReport {
+	return c.ValidateAllWithOptions(ValidationOptions{})
+}
+
+// ValidateAllWithOptions validates c like ValidateAll, plus the optional
+// rules enabled in opts.
+func (c *EMVQR) ValidateAllWithOptions(opts ValidationOptions) *ValidationReport {
+	r := &ValidationReport{}
+	c.validate(r)
+	if opts.CountryCurrency {
+		r.countryCurrency(c)
+	}
+	r.merchantCategory(c, opts)
+	r.duplicates(c)
+	return r
+}
+
+func (c *EMVQR) validate(r *ValidationReport) {
+	// check mandatory
+	r.mandatory(IDPayloadFormatIndicator.String(), "PayloadFormatIndicator", c.PayloadFormatIndicator.Value)
+	if len(c.MerchantAccountInformation) <= 0 && len(c.MerchantAccountPrimitives) <= 0 {
+		r.add(IDMerchantAccountInformationRangeStart.String()+"-"+IDMerchantAccountInformationRangeEnd.String(), "MerchantAccountInformation", RuleMandatory, "", "MerchantAccountInformation is mandatory")
+	}
+	r.mandatory(IDMerchantCategoryCode.String(), "MerchantCategoryCode", c.MerchantCategoryCode.Value)
+	r.mandatory(IDTransactionCurrency.String(), "TransactionCurrency", c.TransactionCurrency.Value)
+	r.mandatory(IDCountryCode.String(), "CountryCode", c.CountryCode.Value)
+	r.mandatory(IDMerchantName.String(), "MerchantName", c.MerchantName.Value)
+	r.mandatory(IDMerchantCity.String(), "MerchantCity", c.MerchantCity.Value)
+	// check validate
+	if v := c.PayloadFormatIndicator.Value; v != "" && v != "01" {
+		r.add(IDPayloadFormatIndicator.String(), "PayloadFormatIndicator", RuleValue, v, "PayloadFormatIndicator should be \"01\", PayloadFormatIndicator: %s", v)
+	}
+	if v := c.PointOfInitiationMethod.Value; v != "" && v != PointOfInitiationMethodStatic && v != PointOfInitiationMethodDynamic {
+		r.add(IDPointOfInitiationMethod.String(), "PointOfInitiationMethod", RuleValue, v, "PointOfInitiationMethod should be \"11\" or \"12\", PointOfInitiationMethod: %s", v)
+	}
+	if v := c.CountryCode.Value; v != "" && !countryCodePattern.MatchString(v) {
+		r.add(IDCountryCode.String(), "CountryCode", RuleValue, v, "CountryCode should be 2 upper case letters, CountryCode: %s", v)
+	} else if _, ok := LookupCountry(v); v != "" && !ok {
+		r.add(IDCountryCode.String(), "CountryCode", RuleValue, v, "CountryCode should be an ISO 3166-1 alpha-2 code, CountryCode: %s", v)
+	}
+	// check format
+	formats := []struct {
+		id       ID
+		name     string
+		tlv      TLV
+		format   Format
+		min, max int
+	}{
+		{IDPayloadFormatIndicator, "PayloadFormatIndicator", c.PayloadFormatIndicator, FormatNumeric, 2, 2},
+		{IDPointOfInitiationMethod, "PointOfInitiationMethod", c.PointOfInitiationMethod, FormatNumeric, 2, 2},
+		{IDMerchantCategoryCode, "MerchantCategoryCode", c.MerchantCategoryCode, FormatNumeric, 4, 4},
+		{IDTransactionCurrency, "TransactionCurrency", c.TransactionCurrency, FormatNumeric, 3, 3},
+		{IDTipOrConvenienceIndicator, "TipOrConvenienceIndicator", c.TipOrConvenienceIndicator, FormatNumeric, 2, 2},
+		{IDCountryCode, "CountryCode", c.CountryCode, FormatAlphanumericSpecial, 2, 2},
+		{IDMerchantName, "MerchantName", c.MerchantName, FormatAlphanumericSpecial, 1, 25},
+		{IDMerchantCity, "MerchantCity", c.MerchantCity, FormatAlphanumericSpecial, 1, 15},
+		{IDPostalCode, "PostalCode", c.PostalCode, FormatAlphanumericSpecial, 1, 10},
+		{IDCRC, "CRC", c.CRC, FormatAlphanumericSpecial, 4, 4},
+	}
+	for _, f := range formats {
+		r.format(f.id.String(), f.name, f.tlv, f.format, f.min, f.max)
+	}
+	r.amount(IDTransactionAmount.String(), "TransactionAmount", c.TransactionAmount)
+	r.amount(IDValueOfConvenienceFeeFixed.String(), "ValueOfConvenienceFeeFixed", c.ValueOfConvenienceFeeFixed)
+	r.percentage(IDValueOfConvenienceFeePercentage.String(), "ValueOfConvenienceFeePercentage", c.ValueOfConvenienceFeePercentage)
+	r.convenience(c)
+	r.currency(c)
+	for _, m := range c.MerchantAccountPrimitives {
+		r.merchantAccountPrimitive(m)
+	}
+	for _, id := range c.merchantAccountInformationIDs() {
+		m := c.MerchantAccountInformation[id]
+		m.validate(r, id)
+	}
+	if c.AdditionalDataFieldTemplate != nil {
+		c.AdditionalDataFieldTemplate.validate(r)
+	}
+	if c.MerchantInformationLanguageTemplate != nil {
+		c.MerchantInformationLanguageTemplate.validate(r)
+	}
+	for _, rfu := range c.RFUforEMVCo {
+		if r.idRange(rfu.Tag.String(), "RFUforEMVCo", rfu.Tag, IDRFUForEMVCoRangeStart, IDRFUForEMVCoRangeEnd) {
+			r.format(rfu.Tag.String(), "RFUforEMVCo", rfu, FormatString, 1, MaxValueLength)
+		}
+	}
+	for _, id := range c.unreservedTemplateIDs() {
+		u := c.UnreservedTemplates[id]
+		u.validate(r, id)
+	}
+}
+
+// merchantAccountPrimitive checks a primitive Merchant Account Information
+// and, for a scheme that uses one, its PAN.
+func (r *ValidationReport) merchantAccountPrimitive(tlv TLV) {
+	path := tlv.Tag.String()
+	if !r.idRange(path, "MerchantAccountPrimitive", tlv.Tag, IDMerchantAccountPrimitiveRangeStart, IDMerchantAccountPrimitiveRangeEnd) {
+		return
+	}
+	r.format(path, "MerchantAccountPrimitive", tlv, FormatAlphanumericSpecial, 1, MaxValueLength)
+	if s, ok := LookupMerchantAccountScheme(tlv.Tag); ok && s.PAN && tlv.Value != "" && !ValidPAN(tlv.Value) {
+		r.add(path, "MerchantAccountPrimitive", RuleValue, tlv.Value, "MerchantAccountPrimitive should be a %s PAN of 8 to 19 digits with a valid check digit, MerchantAccountPrimitive: %s", s.Name, tlv.Value)
+	}
+}
+
+func (s *MerchantAccountInformationTLV) validate(r *ValidationReport, id ID) {
+	path := id.String()
+	if !r.idRange(path, "MerchantAccountInformation", id, IDMerchantAccountTemplateRangeStart, IDMerchantAccountTemplateRangeEnd) {
+		return
+	}
+	if s.Value == nil {
+		r.add(path, "MerchantAccountInformation", RuleMandatory, "", "MerchantAccountInformation %s is empty", id)
+		return
+	}
+	s.Value.validate(r, path)
+	if s.Tag != id || s.Length != l(s.Value.String()) {
+		r.add(path, "MerchantAccountInformation", RuleLengthIndicator, s.Length, "MerchantAccountInformation %s should be tagged %s with length %s, tag: %s, length: %s", id, id, l(s.Value.String()), s.Tag, s.Length)
+	}
+}
+
+func (s *MerchantAccountInformation) validate(r *ValidationReport, path string) {
+	guidPath := tagPath(path, MerchantAccountInformationIDGloballyUniqueIdentifier)
+	// check mandatory
+	r.mandatory(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier.Value)
+	// check format
+	r.format(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier, FormatAlphanumericSpecial, 1, 32)
+	r.guid(guidPath, s.GloballyUniqueIdentifier)
+	for _, pns := range s.PaymentNetworkSpecific {
+		p := tagPath(path, pns.Tag)
+		if r.idRange(p, "PaymentNetworkSpecific", pns.Tag, MerchantAccountInformationIDPaymentNetworkSpecificStart, MerchantAccountInformationIDPaymentNetworkSpecificEnd) {
+			r.format(p, "PaymentNetworkSpecific", pns, FormatAlphanumericSpecial, 1, MaxValueLength)
+		}
+	}
+	r.templateLength(path, "MerchantAccountInformation", s.String())
+}
+
+func (s *AdditionalDataFieldTemplate) validate(r *ValidationReport) {
+	path := IDAdditionalDataFieldTemplate.String()
+	// check format
+	formats := []struct {
+		name     string
+		tlv      TLV
+		id       ID
+		min, max int
+	}{
+		{"BillNumber", s.BillNumber, AdditionalIDBillNumber, 1, 25},
+		{"MobileNumber", s.MobileNumber, AdditionalIDMobileNumber, 1, 25},
+		{"StoreLabel", s.StoreLabel, AdditionalIDStoreLabel, 1, 25},
+		{"LoyaltyNumber", s.LoyaltyNumber, AdditionalIDLoyaltyNumber, 1, 25},
+		{"ReferenceLabel", s.ReferenceLabel, AdditionalIDReferenceLabel, 1, 25},
+		{"CustomerLabel", s.CustomerLabel, AdditionalIDCustomerLabel, 1, 25},
+		{"TerminalLabel", s.TerminalLabel, AdditionalIDTerminalLabel, 1, 25},
+		{"PurposeTransaction", s.PurposeTransaction, AdditionalIDPurposeTransaction, 1, 25},
+		{"AdditionalConsumerDataRequest", s.AdditionalConsumerDataRequest, AdditionalIDAdditionalConsumerDataRequest, 1, 3},
+		{"MerchantTaxID", s.MerchantTaxID, AdditionalIDMerchantTaxID, 1, 20},
+		{"MerchantChannel", s.MerchantChannel, AdditionalIDMerchantChannel, 3, 3},
+	}
+	for _, f := range formats {
+		r.format(tagPath(path, f.id), f.name, f.tlv, FormatAlphanumericSpecial, f.min, f.max)
+	}
+	if v := s.AdditionalConsumerDataRequest.Value; v != "" {
+		if _, err := ParseConsumerDataRequest(v); err != nil {
+			r.add(tagPath(path, AdditionalIDAdditionalConsumerDataRequest), "AdditionalConsumerDataRequest", RuleValue, v, "AdditionalConsumerDataRequest should be a combination of \"A\", \"M\" and \"E\" without repeats, AdditionalConsumerDataRequest: %s", v)
+		}
+	}
+	if v := s.MerchantChannel.Value; v != "" {
+		if _, err := ParseMerchantChannel(v); err != nil {
+			r.add(tagPath(path, AdditionalIDMerchantChannel), "MerchantChannel", RuleValue, v, "MerchantChannel should be a media (0-7), a location (0-3) and a presence (0-3), MerchantChannel: %s", v)
+		}
+	}
+	for _, rfu := range s.RFUforEMVCo {
+		p := tagPath(path, rfu.Tag)
+		if r.idRange(p, "RFUforEMVCo", rfu.Tag, AdditionalIDRFUforEMVCoRangeStart, AdditionalIDRFUforEMVCoRangeEnd) {
+			r.format(p, "RFUforEMVCo", rfu, FormatString, 1, MaxValueLength)
+		}
+	}
+	for _, pss := range s.PaymentSystemSpecific {
+		pss.validate(r, path)
+	}
+	r.templateLength(path, "AdditionalDataFieldTemplate", s.String()[4:])
+}
+
+func (s *PaymentSystemSpecificTemplateTLV) validate(r *ValidationReport, parent string) {
+	path := tagPath(parent, s.Tag)
+	if !r.idRange(path, "PaymentSystemSpecific", s.Tag, AdditionalIDPaymentSystemSpecificTemplatesRangeStart, AdditionalIDPaymentSystemSpecificTemplatesRangeEnd) {
+		return
+	}
+	if s.Value == nil {
+		r.add(path, "PaymentSystemSpecific", RuleMandatory, "", "PaymentSystemSpecific %s is empty", s.Tag)
+		return
+	}
+	s.Value.validate(r, path)
+	if s.Length != l(s.Value.String()) {
+		r.add(path, "PaymentSystemSpecific", RuleLengthIndicator, s.Length, "PaymentSystemSpecific %s length should be %s, length: %s", s.Tag, l(s.Value.String()), s.Length)
+	}
+}
+
+func (s *PaymentSystemSpecificTemplate) validate(r *ValidationReport, path string) {
+	if s.Raw != "" {
+		r.warn(path, "PaymentSystemSpecific", RuleValue, s.Raw, "PaymentSystemSpecific should be a template, PaymentSystemSpecific: %s", s.Raw)
+		r.consumerInput(path, "PaymentSystemSpecific", TLV{Value: s.Raw})
+		r.templateLength(path, "PaymentSystemSpecificTemplate", s.Raw)
+		return
+	}
+	guidPath := tagPath(path, PaymentSystemSpecificIDGloballyUniqueIdentifier)
+	// check mandatory
+	r.mandatory(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier.Value)
+	// check format
+	r.format(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier, FormatAlphanumericSpecial, 1, 32)
+	r.guid(guidPath, s.GloballyUniqueIdentifier)
+	for _, pss := range s.PaymentSystemSpecific {
+		p := tagPath(path, pss.Tag)
+		if r.idRange(p, "PaymentSystemSpecific", pss.Tag, PaymentSystemSpecificIDDataStart, PaymentSystemSpecificIDDataEnd) {
+			r.format(p, "PaymentSystemSpecific", pss, FormatString, 1, MaxValueLength)
+		}
+	}
+	r.templateLength(path, "PaymentSystemSpecificTemplate", s.String())
+}
+
+func (s *MerchantInformationLanguageTemplate) validate(r *ValidationReport) {
+	path := IDMerchantInformationLanguageTemplate.String()
+	// check mandatory
+	r.mandatory(tagPath(path, MerchantInformationIDLanguagePreference), "LanguagePreference", s.LanguagePreference.Value)
+	r.mandatory(tagPath(path, MerchantInformationIDMerchantName), "MerchantName", s.MerchantName.Value)
+	// check format
+	r.format(tagPath(path, MerchantInformationIDLanguagePreference), "LanguagePreference", s.LanguagePreference, FormatAlphanumericSpecial, 2, 2)
+	if v := s.LanguagePreference.Value; len(v) == 2 && !validLanguagePreference(v) {
+		r.add(tagPath(path, MerchantInformationIDLanguagePreference), "LanguagePreference", RuleValue, v, "LanguagePreference should be an ISO 639-1 code, LanguagePreference: %s", v)
+	}
+	r.format(tagPath(path, MerchantInformationIDMerchantName), "MerchantName", s.MerchantName, FormatString, 1, 25)
+	r.format(tagPath(path, MerchantInformationIDMerchantCity), "MerchantCity", s.MerchantCity, FormatString, 1, 15)
+	for _, rfu := range s.RFUforEMVCo {
+		p := tagPath(path, rfu.Tag)
+		if r.idRange(p, "RFUforEMVCo", rfu.Tag, MerchantInformationIDRFUforEMVCoRangeStart, MerchantInformationIDRFUforEMVCoRangeEnd) {
+			r.format(p, "RFUforEMVCo", rfu, FormatString, 1, MaxValueLength)
+		}
+	}
+	r.templateLength(path, "MerchantInformationLanguageTemplate", s.String()[4:])
+}
+
+func (s *UnreservedTemplateTLV) validate(r *ValidationReport, id ID) {
+	path := id.String()
+	if !r.idRange(path, "UnreservedTemplates", id, IDUnreservedTemplatesRangeStart, IDUnreservedTemplatesRangeEnd) {
+		return
+	}
+	if s.Value == nil {
+		r.add(path, "UnreservedTemplates", RuleMandatory, "", "UnreservedTemplates %s is empty", id)
+		return
+	}
+	s.Value.validate(r, path)
+	if s.Tag != id || s.Length != l(s.Value.String()) {
+		r.add(path, "UnreservedTemplates", RuleLengthIndicator, s.Length, "UnreservedTemplates %s should be tagged %s with length %s, tag: %s, length: %s", id, id, l(s.Value.String()), s.Tag, s.Length)
+	}
+}
+
+func (s *UnreservedTemplate) validate(r *ValidationReport, path string) {
+	guidPath := tagPath(path, UnreservedTemplateIDGloballyUniqueIdentifier)
+	// check mandatory
+	r.mandatory(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier.Value)
+	// check format
+	r.format(guidPath, "GloballyUniqueIdentifier", s.GloballyUniqueIdentifier, FormatAlphanumericSpecial, 1, 32)
+	r.guid(guidPath, s.GloballyUniqueIdentifier)
+	for _, cs := range s.ContextSpecificData {
+		p := tagPath(path, cs.Tag)
+		if r.idRange(p, "ContextSpecificData", cs.Tag, UnreservedTemplateIDContextSpecificDataStart, UnreservedTemplateIDContextSpecificDataEnd) {
+			r.format(p, "ContextSpecificData", cs, FormatString, 1, MaxValueLength)
+		}
+	}
+	r.templateLength(path, "UnreservedTemplate", s.String())
+}
@@ -0,0 +1,150 @@
+package mpm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEMVQR_ValidateAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *EMVQR)
+		want   []Violation
+	}{
+		{
+			name:   "ok",
+			modify: func(c *EMVQR) {},
+			want:   nil,
+		},
+		{
+			name: "every violation with tag path",
+			modify: func(c *EMVQR) {
+				c.MerchantName = TLV{}
+				c.SetMerchantCategoryCode("41A")
+				m := new(MerchantAccountInformation)
+				m.AddPaymentNetworkSpecific("01", "abcd")
+				c.AddMerchantAccountInformation(ID("26"), m)
+				a := new(AdditionalDataFieldTemplate)
+				a.SetReferenceLabel("参考")
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			want: []Violation{
+				{
+					Path:     "59",
+					Field:    "MerchantName",
+					Rule:     RuleMandatory,
+					Severity: SeverityError,
+					Message:  "MerchantName is mandatory",
+				},
+				{
+					Path:     "52",
+					Field:    "MerchantCategoryCode",
+					Rule:     RuleLength,
+					Severity: SeverityError,
+					Value:    "41A",
+					Message:  "MerchantCategoryCode should be 4 characters, MerchantCategoryCode: 41A",
+				},
+				{
+					Path:     "52",
+					Field:    "MerchantCategoryCode",
+					Rule:     RuleCharacterSet,
+					Severity: SeverityError,
+					Value:    "41A",
+					Message:  "MerchantCategoryCode should be numeric, MerchantCategoryCode: 41A",
+				},
+				{
+					Path:     "26.00",
+					Field:    "GloballyUniqueIdentifier",
+					Rule:     RuleMandatory,
+					Severity: SeverityError,
+					Message:  "GloballyUniqueIdentifier is mandatory",
+				},
+				{
+					Path:     "62.05",
+					Field:    "ReferenceLabel",
+					Rule:     RuleCharacterSet,
+					Severity: SeverityError,
+					Value:    "参考",
+					Message:  "ReferenceLabel should be alphanumeric special, ReferenceLabel: 参考",
+				},
+			},
+		},
+		{
+			name: "payment system specific template",
+			modify: func(c *EMVQR) {
+				p := new(PaymentSystemSpecificTemplate)
+				p.AddPaymentSystemSpecific("01", "abcd")
+				a := new(AdditionalDataFieldTemplate)
+				a.AddPaymentSystemSpecificTemplate(ID("50"), p)
+				c.SetAdditionalDataFieldTemplate(a)
+			},
+			want: []Violation{
+				{
+					Path:     "62.50.00",
+					Field:    "GloballyUniqueIdentifier",
+					Rule:     RuleMandatory,
+					Severity: SeverityError,
+					Message:  "GloballyUniqueIdentifier is mandatory",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validEMVQR()
+			tt.modify(c)
+			got := c.ValidateAll()
+			if !reflect.DeepEqual(got.Violations, tt.want) {
+				t.Errorf("EMVQR.ValidateAll() = %+v, want %+v", got.Violations, tt.want)
+			}
+			if (got.Err() != nil) != (len(tt.want) > 0) {
+				t.Errorf("ValidationReport.Err() = %v", got.Err())
+			}
+		})
+	}
+}
+
+func TestValidationReport_Error(t *testing.T) {
+	r := &ValidationReport{
+		Violations: []Violation{
+			{Path: "59", Field: "MerchantName", Rule: RuleMandatory, Severity: SeverityError, Message: "MerchantName is mandatory"},
+			{Path: "60", Field: "MerchantCity", Rule: RuleMandatory, Severity: SeverityError, Message: "MerchantCity is mandatory"},
+		},
+	}
+	want := "2 violation(s): 59: MerchantName is mandatory; 60: MerchantCity is mandatory"
+	if got := r.Error(); got != want {
+		t.Errorf("ValidationReport.Error() = %v, want %v", got, want)
+	}
+}
+
+func TestValidationReport_Err(t *testing.T) {
+	r := &ValidationReport{
+		Violations: []Violation{
+			{Path: "58", Field: "CountryCode", Rule: RuleValue, Severity: SeverityWarning, Message: "warning"},
+		},
+	}
+	if err := r.Err(); err != nil {
+		t.Errorf("ValidationReport.Err() = %v, want nil for warnings only", err)
+	}
+	r.Violations = append(r.Violations, Violation{Severity: SeverityError, Message: "error"})
+	if err := r.Err(); err != r {
+		t.Errorf("ValidationReport.Err() = %v, want report", err)
+	}
+}
+
+func TestValidationReport_JSON(t *testing.T) {
+	r := &ValidationReport{
+		Violations: []Violation{
+			{Path: "62.05", Field: "ReferenceLabel", Rule: RuleLength, Severity: SeverityError, Value: "x", Message: "m"},
+		},
+	}
+	got, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"violations":[{"path":"62.05","field":"ReferenceLabel","rule":"length","severity":"error","value":"x","message":"m"}]}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
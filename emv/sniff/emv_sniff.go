@@ -0,0 +1,210 @@
+// Package sniff recognises EMV QR code payloads in scanned strings, which may
+// be wrapped in a URL, padded with whitespace or a byte order mark, or not
+// be EMV at all.
+package sniff
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/100x-fi/emv-qrcode/emv/cpm"
+	"github.com/100x-fi/emv-qrcode/emv/mpm"
+)
+
+// ErrUnknownPayload is returned by Decode for a string without an EMV payload.
+var ErrUnknownPayload = errors.New("unknown payload")
+
+// Kind is the kind of content of a scanned string.
+type Kind int
+
+// const ...
+const (
+	KindUnknown Kind = iota
+	KindMPM          // a Merchant Presented Mode payload
+	KindCPM          // a base64 Consumer Presented Mode payload
+	KindURLMPM       // a URL carrying a Merchant Presented Mode payload
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindMPM:
+		return "MPM"
+	case KindCPM:
+		return "CPM"
+	case KindURLMPM:
+		return "URL-wrapped MPM"
+	}
+	return "unknown"
+}
+
+// Confidence is how sure the classification of a scanned string is.
+type Confidence int
+
+// const ...
+const (
+	ConfidenceNone   Confidence = iota // nothing was recognised
+	ConfidenceLow                      // the payload failed to decode
+	ConfidenceMedium                   // the payload starts like one of its kind
+	ConfidenceHigh                     // the payload is well formed, or decoded
+)
+
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+	return "none"
+}
+
+// Result is a classified scanned string.
+type Result struct {
+	Kind       Kind
+	Confidence Confidence
+	// Payload is the EMV payload extracted from the scanned string, "" for
+	// KindUnknown.
+	Payload string
+	// Format names the recognised non-EMV content of KindUnknown, e.g. "EPC"
+	// or "URL", or is "".
+	Format string
+	// MPM and CPM are set by Decode.
+	MPM *mpm.EMVQR
+	CPM *cpm.EMVQR
+}
+
+// mpmPrefix is the Payload Format Indicator every MPM payload starts with.
+const mpmPrefix = "000201"
+
+// foreignFormats are the prefixes of common non-EMV QR code contents.
+var foreignFormats = []struct {
+	prefix string
+	format string
+}{
+	{"BCD\n", "EPC"},
+	{"BCD\r\n", "EPC"},
+	{"WIFI:", "Wi-Fi"},
+	{"BEGIN:VCARD", "vCard"},
+	{"MECARD:", "MeCard"},
+}
+
+// Sniff classifies s and extracts the EMV payload it carries, without
+// decoding it.
+func Sniff(s string) *Result {
+	s = strings.TrimSpace(strings.Replace(s, "\uFEFF", "", -1))
+	for _, f := range foreignFormats {
+		if strings.HasPrefix(s, f.prefix) {
+			return &Result{Kind: KindUnknown, Confidence: ConfidenceHigh, Format: f.format}
+		}
+	}
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '\r', '\n', '\t':
+			return -1
+		}
+		return r
+	}, s)
+	if c := sniffMPM(s); c != ConfidenceNone {
+		return &Result{Kind: KindMPM, Confidence: c, Payload: s}
+	}
+	if c := sniffCPM(s); c != ConfidenceNone {
+		return &Result{Kind: KindCPM, Confidence: c, Payload: strings.Replace(s, " ", "", -1)}
+	}
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "") {
+		if payload, c := fromURL(u); c != ConfidenceNone {
+			return &Result{Kind: KindURLMPM, Confidence: c, Payload: payload}
+		}
+		return &Result{Kind: KindUnknown, Confidence: ConfidenceHigh, Format: "URL"}
+	}
+	return &Result{Kind: KindUnknown, Confidence: ConfidenceNone}
+}
+
+// sniffMPM returns ConfidenceHigh for s with a Payload Format Indicator and a
+// CRC as the last data object, ConfidenceMedium for s with only the former.
+func sniffMPM(s string) Confidence {
+	if !strings.HasPrefix(s, mpmPrefix) {
+		return ConfidenceNone
+	}
+	runes := []rune(s)
+	if len(runes) >= 8 && string(runes[len(runes)-8:len(runes)-4]) == mpm.IDCRC.String()+"04" {
+		return ConfidenceHigh
+	}
+	return ConfidenceMedium
+}
+
+// sniffCPM returns ConfidenceHigh for base64 s starting with the Payload
+// Format Indicator "CPV01", ConfidenceMedium for one starting with its tag.
+func sniffCPM(s string) Confidence {
+	b, err := base64.StdEncoding.DecodeString(strings.Replace(s, " ", "", -1))
+	if err != nil || len(b) < 2 || b[0] != 0x85 {
+		return ConfidenceNone
+	}
+	if len(b) >= 7 && b[1] == 5 && string(b[2:7]) == "CPV01" {
+		return ConfidenceHigh
+	}
+	return ConfidenceMedium
+}
+
+// fromURL returns the MPM payload in a query parameter, the fragment or a
+// path segment of u.
+func fromURL(u *url.URL) (string, Confidence) {
+	candidates := queryValues(u.Query())
+	if f, err := url.ParseQuery(u.Fragment); err == nil {
+		candidates = append(candidates, queryValues(f)...)
+	}
+	candidates = append(candidates, u.Fragment)
+	for _, seg := range strings.Split(u.EscapedPath(), "/") {
+		if v, err := url.PathUnescape(seg); err == nil {
+			candidates = append(candidates, v)
+		}
+	}
+	best, confidence := "", ConfidenceNone
+	for _, v := range candidates {
+		v = strings.TrimSpace(v)
+		if c := sniffMPM(v); c > confidence {
+			best, confidence = v, c
+		}
+	}
+	return best, confidence
+}
+
+// queryValues returns the values of q ordered by key.
+func queryValues(q url.Values) []string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var values []string
+	for _, k := range keys {
+		values = append(values, q[k]...)
+	}
+	return values
+}
+
+// Decode classifies s like Sniff and decodes its payload with mpm.Decode or
+// cpm.EMVQR.Decode. If decoding fails, the result is returned with
+// ConfidenceLow along with the error.
+func Decode(s string) (*Result, error) {
+	r := Sniff(s)
+	var err error
+	switch r.Kind {
+	case KindMPM, KindURLMPM:
+		r.MPM, err = mpm.Decode(r.Payload)
+	case KindCPM:
+		r.CPM, err = new(cpm.EMVQR).Decode(r.Payload)
+	default:
+		return r, ErrUnknownPayload
+	}
+	if err != nil {
+		r.Confidence = ConfidenceLow
+		return r, err
+	}
+	r.Confidence = ConfidenceHigh
+	return r, nil
+}
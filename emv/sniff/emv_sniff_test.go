@@ -0,0 +1,123 @@
+package sniff
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/100x-fi/emv-qrcode/emv/mpm"
+)
+
+const (
+	mpmPayload = "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304A13A"
+	cpmPayload = "hQVDUFYwMWETTwegAAAAVVVVUAhQcm9kdWN0MWETTwegAAAAZmZmUAhQcm9kdWN0MmJJWggSNFZ4kBI0WF8gDkNBUkRIT0xERVIvRU1WXy0IcnVlc2RlZW5kIZ8QBwYBCgMAAACfJghYT9OF+iNLzJ82AgABnzcEbVjvEw=="
+)
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              string
+		wantKind       Kind
+		wantConfidence Confidence
+		wantPayload    string
+		wantFormat     string
+	}{
+		{
+			name:           "mpm",
+			s:              mpmPayload,
+			wantKind:       KindMPM,
+			wantConfidence: ConfidenceHigh,
+			wantPayload:    mpmPayload,
+		},
+		{
+			name:           "mpm with bom and whitespace",
+			s:              "\uFEFF  " + mpmPayload[:60] + "\r\n" + mpmPayload[60:] + "\n",
+			wantKind:       KindMPM,
+			wantConfidence: ConfidenceHigh,
+			wantPayload:    mpmPayload,
+		},
+		{
+			name:           "mpm without crc",
+			s:              "000201010211",
+			wantKind:       KindMPM,
+			wantConfidence: ConfidenceMedium,
+			wantPayload:    "000201010211",
+		},
+		{
+			name:           "cpm",
+			s:              " " + cpmPayload + "\n",
+			wantKind:       KindCPM,
+			wantConfidence: ConfidenceHigh,
+			wantPayload:    cpmPayload,
+		},
+		{
+			name:           "url query",
+			s:              "https://pay.example.com/qr?v=1&data=" + url.QueryEscape(mpmPayload),
+			wantKind:       KindURLMPM,
+			wantConfidence: ConfidenceHigh,
+			wantPayload:    mpmPayload,
+		},
+		{
+			name:           "url path",
+			s:              "https://pay.example.com/qr/" + url.PathEscape(mpmPayload),
+			wantKind:       KindURLMPM,
+			wantConfidence: ConfidenceHigh,
+			wantPayload:    mpmPayload,
+		},
+		{
+			name:           "plain url",
+			s:              "https://example.com/menu",
+			wantKind:       KindUnknown,
+			wantConfidence: ConfidenceHigh,
+			wantFormat:     "URL",
+		},
+		{
+			name:           "epc",
+			s:              "BCD\n002\n1\nSCT\nBPOTBEB1\nRed Cross\nBE72000000001616\nEUR1\n",
+			wantKind:       KindUnknown,
+			wantConfidence: ConfidenceHigh,
+			wantFormat:     "EPC",
+		},
+		{
+			name:           "text",
+			s:              "hello",
+			wantKind:       KindUnknown,
+			wantConfidence: ConfidenceNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sniff(tt.s)
+			if got.Kind != tt.wantKind || got.Confidence != tt.wantConfidence || got.Payload != tt.wantPayload || got.Format != tt.wantFormat {
+				t.Errorf("Sniff() = %v %v %q %q, want %v %v %q %q", got.Kind, got.Confidence, got.Payload, got.Format, tt.wantKind, tt.wantConfidence, tt.wantPayload, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	r, err := Decode("https://pay.example.com/qr?data=" + url.QueryEscape(mpmPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != KindURLMPM || r.Confidence != ConfidenceHigh || r.MPM == nil || r.MPM.MerchantName.Value != "BEST TRANSPORT" {
+		t.Errorf("Decode() = %+v", r)
+	}
+
+	r, err = Decode(cpmPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != KindCPM || r.CPM == nil || r.CPM.DataPayloadFormatIndicator != "CPV01" {
+		t.Errorf("Decode() = %+v", r)
+	}
+
+	r, err = Decode(mpmPayload[:len(mpmPayload)-4] + "0000")
+	if !errors.Is(err, mpm.ErrCRCMismatch) || r.Kind != KindMPM || r.Confidence != ConfidenceLow {
+		t.Errorf("Decode() = %+v, %v, want ErrCRCMismatch", r, err)
+	}
+
+	if _, err := Decode("hello"); !errors.Is(err, ErrUnknownPayload) {
+		t.Errorf("Decode() error = %v, want ErrUnknownPayload", err)
+	}
+}
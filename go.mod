@@ -1,5 +1,8 @@
 module github.com/100x-fi/emv-qrcode
 
-go 1.12
+go 1.13
 
-require github.com/dongri/emv-qrcode v0.1.1
+require (
+	github.com/dongri/emv-qrcode v0.1.1
+	golang.org/x/text v0.3.6
+)
@@ -25,7 +25,7 @@ func main() {
 
 	emvqr.SetMerchantCategoryCode("5311")
 	emvqr.SetTransactionCurrency("392")
-	emvqr.SetTransactionAmount("999.123")
+	emvqr.SetTransactionAmount("999") // JPY has no minor units
 	emvqr.SetCountryCode("JP")
 	emvqr.SetMerchantName("DONGRI")
 	emvqr.SetMerchantCity("TOKYO")
@@ -39,7 +39,7 @@ func main() {
 		log.Println(err.Error())
 		return
 	}
-	log.Println(code) // 0002010102121313JCB12345678900416MASTER12345678905204531153033925407999.1235802JP5906DONGRI6005TOKYO62240104hoge0504fuga0704piyo6304C343
+	log.Println(code) // 00020101021229280007D1234561313JCB123456789031310007M1234560416MASTER123456789052045311530339254039995802JP5906DONGRI6005TOKYO62240104hoge0504fuga0704piyo63043AA8
 
 	// MPM Decode
 	emvqr, err = mpm.Decode("00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304A13A")